@@ -3,32 +3,178 @@ package main
 import (
 	"fmt"
 	"log"
+	"os"
+	"time"
 
 	"github.com/alistairjudson/cronparse"
 	"github.com/spf13/cobra"
 )
 
+// dialects are the presets that can be chosen with the dialect flag
+var dialects = map[string]cronparse.Option{
+	"vixie":      cronparse.Vixie,
+	"quartz":     cronparse.Quartz,
+	"aws":        cronparse.AWS,
+	"kubernetes": cronparse.Kubernetes,
+	"robfig":     cronparse.Robfig,
+}
+
+// parserFlags are the flags that choose the parser for an expression
+type parserFlags struct {
+	seconds, year, quartz, wrapAround bool
+	dialect                           string
+}
+
 func main() {
+	var flags parserFlags
 	cmd := &cobra.Command{
 		Use:   "cronparse",
 		Short: "a utility for parsing cron strings",
 		Long:  "a utility to expand cron strings into the periods that it would run on",
+		Args:  cobra.ArbitraryArgs,
 		Run: func(cmd *cobra.Command, args []string) {
-			const numArgs = 6
-			if len(args) != numArgs {
-				log.Fatal("please provide 6 arguments")
+			if len(args) == 0 {
+				log.Fatal("please provide a cron expression followed by a command")
 			}
-			parsed, err := cronparse.CronParser.Parse(args[0:5])
+			components, schedule, command, err := parse(flags.parser(), args, true)
 			if err != nil {
-				log.Fatal(err)
+				fmt.Fprintln(os.Stderr, cronparse.Diagnostic(components, err))
+				os.Exit(1)
 			}
-			for _, part := range parsed {
+			if schedule.Kind != cronparse.ScheduleKindCron {
+				fmt.Printf("%-14s %s\n", "kind", schedule.Kind)
+			}
+			if schedule.Kind == cronparse.ScheduleKindEvery {
+				fmt.Printf("%-14s %s\n", "interval", schedule.Interval)
+			}
+			for _, part := range schedule.Components() {
 				fmt.Println(part)
 			}
-			fmt.Printf("%-14s %s\n", "command", args[5])
+			if schedule.EitherDay() {
+				fmt.Printf("%-14s %s\n", "days", "day of month or day of week")
+			}
+			if schedule.Location != nil {
+				fmt.Printf("%-14s %s\n", "time zone", schedule.Location)
+			}
+			fmt.Printf("%-14s %s\n", "command", command)
 		},
 	}
+	cmd.PersistentFlags().BoolVarP(&flags.seconds, "seconds", "s", false, "the expression starts with a seconds component")
+	cmd.PersistentFlags().BoolVarP(&flags.quartz, "quartz", "q", false, "the expression is a Quartz expression, with seconds and (L W # ?)")
+	cmd.PersistentFlags().BoolVarP(&flags.year, "year", "y", false, "the expression ends with a year component")
+	cmd.PersistentFlags().StringVarP(
+		&flags.dialect, "dialect", "d", "",
+		"the dialect of the expression: vixie, quartz, aws, kubernetes or robfig",
+	)
+	cmd.PersistentFlags().BoolVarP(&flags.wrapAround, "wrap-around", "w", false, "allow ranges that wrap around e.g. 22-2 or FRI-MON")
+	cmd.AddCommand(checkCommand(&flags))
 	if err := cmd.Execute(); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// checkCommand creates the command that explains whether an expression runs
+// at a time
+func checkCommand(flags *parserFlags) *cobra.Command {
+	var at string
+	cmd := &cobra.Command{
+		Use:   "check --at <time> <expression>",
+		Short: "explain whether an expression runs at a time",
+		Long: "explain whether an expression runs at a time, and which part of each component matched it. " +
+			"The time is RFC 3339, or (2006-01-02 15:04:05) in the time zone of the expression or the local time zone.",
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			components, schedule, _, err := parse(flags.parser(), args, false)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, cronparse.Diagnostic(components, err))
+				os.Exit(1)
+			}
+			t, wall, err := parseTime(at)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if !wall {
+				fmt.Println(schedule.Explain(t))
+				return
+			}
+			fmt.Println(schedule.ExplainDate(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local))
+		},
+	}
+	cmd.Flags().StringVar(&at, "at", "", "the time to check e.g. 2006-01-02T15:04:05Z or (2006-01-02 15:04)")
+	if err := cmd.MarkFlagRequired("at"); err != nil {
+		log.Fatal(err)
+	}
+	return cmd
+}
+
+// timeLayouts are the layouts that the time to check can be given in, the
+// layouts without an offset are in the time zone of the expression
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+}
+
+// parseTime will parse a time in one of the timeLayouts, and tell you whether
+// it is a wall clock time, which is parsed in UTC so that a time that the
+// clocks go forward over in the time zone of the expression isn't moved on
+func parseTime(value string) (time.Time, bool, error) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, layout != time.RFC3339Nano, nil
+		}
+	}
+	return time.Time{}, false, fmt.Errorf("time (%s) must be RFC 3339 or (2006-01-02 15:04:05)", value)
+}
+
+// parser returns the parser that is chosen by the flags
+func (f parserFlags) parser() cronparse.Parser {
+	parser := cronparse.CronParser
+	switch {
+	case f.quartz && f.year:
+		parser = cronparse.QuartzYearParser
+	case f.quartz:
+		parser = cronparse.QuartzParser
+	case f.seconds && f.year:
+		parser = cronparse.SecondsYearCronParser
+	case f.seconds:
+		parser = cronparse.SecondsCronParser
+	case f.year:
+		parser = cronparse.YearCronParser
+	}
+	if f.dialect != "" {
+		if f.seconds || f.year || f.quartz {
+			log.Fatal("--dialect cannot be used with --seconds, --year or --quartz")
+		}
+		preset, ok := dialects[f.dialect]
+		if !ok {
+			log.Fatalf("unknown dialect (%s), expected vixie, quartz, aws, kubernetes or robfig", f.dialect)
+		}
+		var err error
+		if parser, err = cronparse.New(preset); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if f.wrapAround {
+		parser = parser.WithWrapAround()
+	}
+	return parser.WithAllErrors()
+}
+
+// parse will parse the arguments into a schedule and a command, a single
+// argument is parsed as a whole line, such as a line from a crontab, otherwise
+// the last argument is the command if there is one
+func parse(parser cronparse.Parser, args []string, hasCommand bool) ([]string, *cronparse.Schedule, string, error) {
+	if len(args) == 1 {
+		expr, err := parser.ParseExpression(args[0])
+		return expr.Components, expr.Schedule, expr.Command, err
+	}
+	components, command := args, ""
+	if hasCommand {
+		components, command = args[:len(args)-1], args[len(args)-1]
+	}
+	schedule, err := parser.Schedule(components)
+	return components, schedule, command, err
+}
@@ -0,0 +1,92 @@
+package cronparse
+
+import (
+	"strings"
+	"time"
+)
+
+// DayPolicy decides how a Schedule combines the day of month and day of week
+// components to find the days that it runs on
+type DayPolicy int
+
+// Policies for combining the day of month and day of week
+const (
+	// DaysVixie matches the behaviour of Vixie cron. When both the day of
+	// month and the day of week are restricted, the schedule runs on the days
+	// that match either of them, so (0 0 1 * MON) runs on the 1st of the month
+	// and on every monday. When either of them is a wildcard, a component
+	// that starts with (*) or (?), it runs on the days that match both.
+	DaysVixie DayPolicy = iota
+	// DaysAnd will only run on the days that match both the day of month and
+	// the day of week, as Quartz does
+	DaysAnd
+)
+
+var dayPolicyNames = map[DayPolicy]string{
+	DaysVixie: "vixie",
+	DaysAnd:   "and",
+}
+
+// String implements fmt.Stringer and returns the name of the policy
+func (d DayPolicy) String() string {
+	return dayPolicyNames[d]
+}
+
+// EitherDay tells you whether the schedule runs on the days that match either
+// the day of month or the day of week, rather than both of them, which is the
+// case with DaysVixie when neither of them is a wildcard. It is false for the
+// schedules that aren't cron schedules, as they don't have days.
+func (s *Schedule) EitherDay() bool {
+	return s.Kind == ScheduleKindCron && s.Days == DaysVixie && !s.dayOfMonthWildcard && !s.dayOfWeekWildcard
+}
+
+// dayMatches tells you whether the schedule runs on a given date, the day of
+// month and the day of week match by containing the number or by one of their
+// date matchers, and are combined by the DayPolicy of the schedule
+func (s *Schedule) dayMatches(year, month, day int) bool {
+	dayOfMonth := s.dayOfMonthMatches(year, month, day)
+	dayOfWeek := s.dayOfWeekMatches(year, month, day)
+	if s.EitherDay() {
+		return dayOfMonth || dayOfWeek
+	}
+	return dayOfMonth && dayOfWeek
+}
+
+// dayOfMonthMatches tells you whether the day of month component matches a
+// date, by containing the day or by one of its date matchers
+func (s *Schedule) dayOfMonthMatches(year, month, day int) bool {
+	return s.dayOfMonth.Contains(day) || matchesDate(s.dayOfMonthMatchers, year, month, day)
+}
+
+// dayOfWeekMatches tells you whether the day of week component matches a
+// date, by containing its weekday or by one of its date matchers
+func (s *Schedule) dayOfWeekMatches(year, month, day int) bool {
+	return s.dayOfWeek.Contains(int(weekdayOf(year, month, day))) ||
+		matchesDate(s.dayOfWeekMatchers, year, month, day)
+}
+
+// weekdayOf returns the day of the week of a date
+func weekdayOf(year, month, day int) time.Weekday {
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC).Weekday()
+}
+
+func matchesDate(matchers []DateMatcher, year, month, day int) bool {
+	for _, matcher := range matchers {
+		if matcher.MatchesDate(year, time.Month(month), day) {
+			return true
+		}
+	}
+	return false
+}
+
+// isNoSpecific tells you whether a component is the Quartz (?)
+func isNoSpecific(component string) bool {
+	return strings.HasPrefix(component, "?")
+}
+
+// isWildcard tells you whether a component is a wildcard, in the same way as
+// Vixie cron, which is by whether it starts with (*), so (*/2) is also a
+// wildcard, or with the Quartz (?)
+func isWildcard(component string) bool {
+	return strings.HasPrefix(component, "*") || strings.HasPrefix(component, "?")
+}
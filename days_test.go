@@ -0,0 +1,147 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestSchedule_Days(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		days       cronparse.DayPolicy
+		from       string
+		either     bool
+		expected   []string
+	}{
+		{
+			name:       "both restricted runs on either",
+			expression: "0 0 1 * MON",
+			from:       "2020-01-01T00:00:00Z",
+			either:     true,
+			expected: []string{
+				"2020-01-06T00:00:00Z", "2020-01-13T00:00:00Z", "2020-01-20T00:00:00Z", "2020-01-27T00:00:00Z", "2020-02-01T00:00:00Z",
+			},
+		},
+		{
+			name:       "day of month wildcard",
+			expression: "0 0 * * MON",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   []string{"2020-01-06T00:00:00Z", "2020-01-13T00:00:00Z"},
+		},
+		{
+			name:       "day of week wildcard",
+			expression: "0 0 1 * *",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   []string{"2020-02-01T00:00:00Z", "2020-03-01T00:00:00Z"},
+		},
+		{
+			name:       "stepped wildcard is still a wildcard",
+			expression: "0 0 */2 * MON",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   []string{"2020-01-13T00:00:00Z", "2020-01-27T00:00:00Z", "2020-02-03T00:00:00Z"},
+		},
+		{
+			name:       "full range is not a wildcard",
+			expression: "0 0 1-31 * MON",
+			from:       "2020-01-01T00:00:00Z",
+			either:     true,
+			expected:   []string{"2020-01-02T00:00:00Z", "2020-01-03T00:00:00Z"},
+		},
+		{
+			name:       "and",
+			expression: "0 0 13 * FRI",
+			days:       cronparse.DaysAnd,
+			from:       "2020-01-01T00:00:00Z",
+			expected:   []string{"2020-03-13T00:00:00Z", "2020-11-13T00:00:00Z"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule := mustSchedule(t, test.expression)
+			schedule.Days = test.days
+			if got := schedule.EitherDay(); got != test.either {
+				t.Fatalf("expected (%t), got (%t)", test.either, got)
+			}
+			got := make([]string, 0, len(test.expected))
+			for next := mustTime(t, test.from); len(got) < len(test.expected); {
+				next = schedule.Next(next)
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_EitherDayMacros(t *testing.T) {
+	for _, expression := range []string{"@every 1h30m", "@reboot"} {
+		t.Run(expression, func(t *testing.T) {
+			if mustSchedule(t, expression).EitherDay() {
+				t.Fatal("expected (false), got (true)")
+			}
+		})
+	}
+}
+
+func TestSchedule_PrevEitherDay(t *testing.T) {
+	got := mustSchedule(t, "0 0 1 * MON").Prev(mustTime(t, "2020-02-02T00:00:00Z"))
+	expected := mustTime(t, "2020-02-01T00:00:00Z")
+	if !expected.Equal(got) {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+}
+
+func TestParser_ScheduleDayPolicy(t *testing.T) {
+	vixie, err := cronparse.New(cronparse.Vixie)
+	if err != nil {
+		t.Fatal(err)
+	}
+	aws, err := cronparse.New(cronparse.AWS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+		expected   cronparse.DayPolicy
+	}{
+		{name: "cron", parser: cronparse.CronParser, expression: "0 0 1 * MON", expected: cronparse.DaysVixie},
+		{name: "vixie", parser: vixie, expression: "0 0 1 * MON", expected: cronparse.DaysVixie},
+		{name: "quartz", parser: cronparse.QuartzParser, expression: "0 0 0 1 * ?", expected: cronparse.DaysAnd},
+		{name: "quartz years", parser: cronparse.QuartzYearParser, expression: "0 0 0 ? * MON 2020", expected: cronparse.DaysAnd},
+		{name: "aws", parser: aws, expression: "0 0 ? * MON 2020", expected: cronparse.DaysAnd},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := test.parser.Schedule(strings.Fields(test.expression))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if schedule.Days != test.expected {
+				t.Fatalf("expected (%s), got (%s)", test.expected, schedule.Days)
+			}
+		})
+	}
+}
+
+func TestParser_ParseWildcard(t *testing.T) {
+	res, err := cronparse.QuartzParser.Parse(strings.Fields("* */5 1-23 ? * MON"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []bool{true, true, false, true, true, false}
+	got := make([]bool, 0, len(res))
+	for _, res := range res {
+		got = append(got, res.Wildcard)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
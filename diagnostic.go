@@ -0,0 +1,72 @@
+package cronparse
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+)
+
+// diagnosticIndent is the indent of the lines of a diagnostic after the first
+const diagnosticIndent = "    "
+
+// Diagnostic will render an error from parsing the components like a compiler
+// would, it echoes the expression, draws a caret under the problem, and says
+// what the component allows, along with any suggested fixes e.g.
+//
+//	(minute): step (0) must be in range (1-60)
+//	    */0 0 1,15 * 1-5
+//	      ^
+//	    minute allows a number (0-59) or (*)
+//
+// Errors that don't have a position within a component are rendered as they
+// are, and each of the Errors from a parser created with WithAllErrors is
+// rendered in turn.
+func Diagnostic(components []string, err error) string {
+	if err == nil {
+		return ""
+	}
+	var errs Errors
+	if errors.As(err, &errs) {
+		diagnostics := make([]string, 0, len(errs))
+		for _, err := range errs {
+			diagnostics = append(diagnostics, Diagnostic(components, err))
+		}
+		return strings.Join(diagnostics, "\n")
+	}
+	var located cronerr.Located
+	if !errors.As(err, &located) {
+		return err.Error()
+	}
+	position := located.Where()
+	if position.Field == "" || position.Index >= len(components) {
+		return err.Error()
+	}
+	component := components[position.Index]
+	if position.Offset < 0 || position.Offset+position.Length > len(component) {
+		return err.Error()
+	}
+	before := component[:position.Offset]
+	if position.Index > 0 {
+		before = strings.Join(components[:position.Index], " ") + " " + before
+	}
+	carets := utf8.RuneCountInString(component[position.Offset : position.Offset+position.Length])
+	if carets == 0 {
+		carets = 1
+	}
+	var diagnostic strings.Builder
+	fmt.Fprintln(&diagnostic, err)
+	fmt.Fprintln(&diagnostic, diagnosticIndent+strings.Join(components, " "))
+	diagnostic.WriteString(diagnosticIndent)
+	diagnostic.WriteString(strings.Repeat(" ", utf8.RuneCountInString(before)))
+	diagnostic.WriteString(strings.Repeat("^", carets))
+	if position.Allowed != "" {
+		fmt.Fprintf(&diagnostic, "\n%s%s allows %s", diagnosticIndent, position.Field, position.Allowed)
+	}
+	for _, suggestion := range Suggestions(err) {
+		fmt.Fprintf(&diagnostic, "\n%shelp: %s", diagnosticIndent, suggestion.Message)
+	}
+	return diagnostic.String()
+}
@@ -0,0 +1,167 @@
+package cronparse_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestDiagnostic(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+		expected   []string
+	}{
+		{
+			name:       "step",
+			parser:     cronparse.CronParser,
+			expression: "*/0 0 1,15 * 1-5",
+			expected: []string{
+				"(minute): step (0) must be in range (1-60)",
+				"    */0 0 1,15 * 1-5",
+				"      ^",
+				"    minute allows a number (0-59) or (*)",
+			},
+		},
+		{
+			name:       "name",
+			parser:     cronparse.CronParser,
+			expression: "5 * * JAN-FOO *",
+			expected: []string{
+				"(month): (FOO) is unexpected as a name, expected a number (1-12) or a name (JAN-DEC)",
+				"    5 * * JAN-FOO *",
+				"              ^^^",
+				"    month allows a number (1-12), a name (JAN-DEC) or (*)",
+			},
+		},
+		{
+			name:       "quartz day of month",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 W * ?",
+			expected: []string{
+				"(day of month): (W) is unexpected in the day of month, expected (L), (L-n), (LW) or (nW)",
+				"    0 0 0 W * ?",
+				"          ^",
+				"    day of month allows a number (1-31), (*), (?), (L), (L-n), (LW) or (nW)",
+			},
+		},
+		{
+			name:       "quartz day of week",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 ? * 9",
+			expected: []string{
+				"(day of week): number (9) must be in range (1-7)",
+				"    0 0 0 ? * 9",
+				"              ^",
+				"    day of week allows a number (1-7), a name (SUN-SAT), (*), (?), (L), (nL) or (n#m)",
+			},
+		},
+		{
+			name:       "missing value",
+			parser:     cronparse.CronParser,
+			expression: "1, * * * *",
+			expected: []string{
+				"(minute): missing value after (,), expected (*) or a number",
+				"    1, * * * *",
+				"      ^",
+				"    minute allows a number (0-59) or (*)",
+			},
+		},
+		{
+			name:       "after a time zone and a multibyte character",
+			parser:     cronparse.CronParser,
+			expression: "CRON_TZ=UTC € 60 * * *",
+			expected: []string{
+				"(minute): (€) is unexpected at the start of the field, expected (*) or a number",
+				"    CRON_TZ=UTC € 60 * * *",
+				"                ^",
+				"    minute allows a number (0-59) or (*)",
+			},
+		},
+		{
+			name:       "after a multibyte character",
+			parser:     cronparse.CronParser,
+			expression: "0 0 1,€ * *",
+			expected: []string{
+				"(day of month): (€) is unexpected after (,), expected (*) or a number",
+				"    0 0 1,€ * *",
+				"          ^",
+				"    day of month allows a number (1-31) or (*)",
+			},
+		},
+		{
+			name:       "backwards range",
+			parser:     cronparse.CronParser,
+			expression: "0 0 * * FRI-MON",
+			expected: []string{
+				"(day of week): start of the range (FRI) must not be after the end (MON)",
+				"    0 0 * * FRI-MON",
+				"            ^^^^^^^",
+				"    day of week allows a number (0-7), a name (SUN-SAT) or (*)",
+				"    help: did you mean FRI-SAT,SUN-MON? or allow ranges that wrap around",
+			},
+		},
+		{
+			name:       "no position",
+			parser:     cronparse.CronParser,
+			expression: "* * * *",
+			expected:   []string{"expected (5) components, got (4) components"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			components := strings.Fields(test.expression)
+			_, err := test.parser.Parse(components)
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			expected := strings.Join(test.expected, "\n")
+			if got := cronparse.Diagnostic(components, err); got != expected {
+				t.Fatalf("expected (\n%s\n), got (\n%s\n)", expected, got)
+			}
+		})
+	}
+}
+
+func TestDiagnostic_AllErrors(t *testing.T) {
+	components := strings.Fields("60 5a * * 8")
+	_, err := cronparse.CronParser.WithAllErrors().Parse(components)
+	expected := strings.Join([]string{
+		"(minute): number (60) must be in range (0-59)",
+		"    60 5a * * 8",
+		"    ^^",
+		"    minute allows a number (0-59) or (*)",
+		"    help: did you mean 0 (the start of the hour)?",
+		"(hour): (a) is unexpected after a number, expected (,), (-) or (/)",
+		"    60 5a * * 8",
+		"        ^",
+		"    hour allows a number (0-23) or (*)",
+		"(day of week): number (8) must be in range (0-7)",
+		"    60 5a * * 8",
+		"              ^",
+		"    day of week allows a number (0-7), a name (SUN-SAT) or (*)",
+	}, "\n")
+	if got := cronparse.Diagnostic(components, err); got != expected {
+		t.Fatalf("expected (\n%s\n), got (\n%s\n)", expected, got)
+	}
+}
+
+func TestDiagnostic_NoError(t *testing.T) {
+	if got := cronparse.Diagnostic([]string{"*"}, nil); got != "" {
+		t.Fatalf("expected no diagnostic, got (%s)", got)
+	}
+}
+
+func TestDiagnostic_WithoutComponent(t *testing.T) {
+	err := &cronparse.StepError{Value: "0", Min: 1, Max: 60}
+	if got := cronparse.Diagnostic([]string{"*/0"}, err); got != err.Error() {
+		t.Fatalf("expected (%s), got (%s)", err, got)
+	}
+	wrapped := errors.New("an error")
+	if got := cronparse.Diagnostic([]string{"*"}, wrapped); got != wrapped.Error() {
+		t.Fatalf("expected (%s), got (%s)", wrapped, got)
+	}
+}
@@ -0,0 +1,280 @@
+package cronparse
+
+import (
+	"github.com/alistairjudson/cronparse/internal/numberer"
+	"github.com/alistairjudson/cronparse/internal/parse"
+)
+
+// dialect is the flavour of cron that a parser created with New parses
+type dialect struct {
+	seconds        bool
+	years          *numberer.Range
+	names          bool
+	macros         bool
+	timeZone       bool
+	quartzSpecials bool
+	quartzWeekdays bool
+	noSpecificDay  bool
+	sundaySeven    bool
+	days           DayPolicy
+	dst            DSTPolicy
+}
+
+// Option is a type that changes the dialect of cron that New creates a parser
+// for, the options are applied in order so later options win
+type Option func(d *dialect)
+
+// New will create a Parser for a dialect of cron, without any options it
+// parses the same expressions as CronParser: the five standard components,
+// with the names of the months and days, 7 as sunday, macros such as @daily,
+// and a leading time zone such as CRON_TZ=Europe/London
+func New(opts ...Option) (Parser, error) {
+	d := dialect{
+		names:       true,
+		macros:      true,
+		timeZone:    true,
+		sundaySeven: true,
+	}
+	for _, opt := range opts {
+		opt(&d)
+	}
+	return d.parser()
+}
+
+// WithSeconds is an Option for a leading seconds component, as used by Quartz
+// and Spring
+func WithSeconds(enabled bool) Option {
+	return func(d *dialect) {
+		d.seconds = enabled
+	}
+}
+
+// WithYears is an Option for a trailing year component, which accepts the
+// years from start to end, as used by Quartz and AWS
+func WithYears(start, end int) Option {
+	return func(d *dialect) {
+		d.years = &numberer.Range{Start: start, End: end}
+	}
+}
+
+// WithoutYears is an Option that removes the year component, e.g. from a
+// preset that has one
+func WithoutYears() Option {
+	return func(d *dialect) {
+		d.years = nil
+	}
+}
+
+// WithNames is an Option for the names of the months (JAN-DEC) and days
+// (SUN-SAT), in place of their numbers
+func WithNames(enabled bool) Option {
+	return func(d *dialect) {
+		d.names = enabled
+	}
+}
+
+// WithMacros is an Option for the macros, such as @daily, @reboot or @every,
+// in place of the components
+func WithMacros(enabled bool) Option {
+	return func(d *dialect) {
+		d.macros = enabled
+	}
+}
+
+// WithTimeZone is an Option for a time zone before the components, such as
+// CRON_TZ=Europe/London
+func WithTimeZone(enabled bool) Option {
+	return func(d *dialect) {
+		d.timeZone = enabled
+	}
+}
+
+// WithQuartzSpecials is an Option for the Quartz special characters (L W # ?)
+// in the day of month and day of week components
+func WithQuartzSpecials(enabled bool) Option {
+	return func(d *dialect) {
+		d.quartzSpecials = enabled
+	}
+}
+
+// WithQuartzWeekdays is an Option that numbers the days of the week from 1-7
+// (SUN-SAT) as Quartz does, in place of 0-6
+func WithQuartzWeekdays(enabled bool) Option {
+	return func(d *dialect) {
+		d.quartzWeekdays = enabled
+	}
+}
+
+// WithNoSpecificDay is an Option that requires the Quartz (?) in exactly one
+// of the day of month and day of week components, as Quartz and AWS do
+func WithNoSpecificDay(enabled bool) Option {
+	return func(d *dialect) {
+		d.noSpecificDay = enabled
+	}
+}
+
+// WithSundaySeven is an Option for 7 as well as 0 as sunday in the day of
+// week component, as in Vixie cron, it has no effect with WithQuartzWeekdays
+func WithSundaySeven(enabled bool) Option {
+	return func(d *dialect) {
+		d.sundaySeven = enabled
+	}
+}
+
+// WithDayPolicy is an Option for how the schedules that the parser creates
+// combine the day of month and day of week, DaysVixie by default
+func WithDayPolicy(policy DayPolicy) Option {
+	return func(d *dialect) {
+		d.days = policy
+	}
+}
+
+// WithDSTPolicy is an Option for what the schedules that the parser creates
+// do when the clocks change, DSTVixie by default
+func WithDSTPolicy(policy DSTPolicy) Option {
+	return func(d *dialect) {
+		d.dst = policy
+	}
+}
+
+// options combines options into one, for the presets
+func options(opts ...Option) Option {
+	return func(d *dialect) {
+		for _, opt := range opts {
+			opt(d)
+		}
+	}
+}
+
+var (
+	// Vixie is an Option for the dialect of Vixie cron, and the crons derived
+	// from it, which don't take a time zone before the components
+	Vixie = options(
+		WithSeconds(false),
+		WithoutYears(),
+		WithNames(true),
+		WithMacros(true),
+		WithTimeZone(false),
+		WithQuartzSpecials(false),
+		WithQuartzWeekdays(false),
+		WithNoSpecificDay(false),
+		WithSundaySeven(true),
+		WithDayPolicy(DaysVixie),
+		WithDSTPolicy(DSTVixie),
+	)
+
+	// Quartz is an Option for the dialect of the Quartz scheduler, which has
+	// a seconds component, the special characters (L W # ?) and the days of
+	// the week numbered 1-7, but no macros. Add WithYears for expressions
+	// with its optional year component.
+	Quartz = options(
+		WithSeconds(true),
+		WithoutYears(),
+		WithNames(true),
+		WithMacros(false),
+		WithTimeZone(false),
+		WithQuartzSpecials(true),
+		WithQuartzWeekdays(true),
+		WithNoSpecificDay(true),
+		WithDayPolicy(DaysAnd),
+		WithDSTPolicy(DSTVixie),
+	)
+
+	// AWS is an Option for the dialect of the cron expressions of AWS
+	// EventBridge, which have a year component (1970-2199), the special
+	// characters (L W # ?) and the days of the week numbered 1-7, but no
+	// seconds
+	AWS = options(
+		WithSeconds(false),
+		WithYears(1970, 2199),
+		WithNames(true),
+		WithMacros(false),
+		WithTimeZone(false),
+		WithQuartzSpecials(true),
+		WithQuartzWeekdays(true),
+		WithNoSpecificDay(true),
+		WithDayPolicy(DaysAnd),
+		WithDSTPolicy(DSTVixie),
+	)
+
+	// Robfig is an Option for the dialect of the standard parser of
+	// github.com/robfig/cron, with macros and a time zone, where the days of
+	// the week are only 0-6
+	Robfig = options(
+		WithSeconds(false),
+		WithoutYears(),
+		WithNames(true),
+		WithMacros(true),
+		WithTimeZone(true),
+		WithQuartzSpecials(false),
+		WithQuartzWeekdays(false),
+		WithNoSpecificDay(false),
+		WithSundaySeven(false),
+		WithDayPolicy(DaysVixie),
+		WithDSTPolicy(DSTVixie),
+	)
+
+	// Kubernetes is an Option for the dialect of Kubernetes CronJobs, which
+	// use the standard parser of github.com/robfig/cron
+	Kubernetes = Robfig
+)
+
+// parser will create the Parser for the dialect
+func (d dialect) parser() (Parser, error) {
+	dayOfMonthParser, dayOfWeekParser := parse.NewParser, parse.NewParser
+	if d.quartzSpecials {
+		dayOfMonthParser, dayOfWeekParser = parse.NewQuartzDayOfMonthParser, parse.NewQuartzDayOfWeekParser
+	}
+	var parser Parser
+	if d.seconds {
+		parser = append(parser, newComponentParser(componentSecond, numberer.SecondFactory, parse.NewParser))
+	}
+	parser = append(parser,
+		newComponentParser(componentMinute, numberer.MinuteFactory, parse.NewParser),
+		newComponentParser(componentHour, numberer.HourFactory, parse.NewParser),
+		newComponentParser(componentDayOfMonth, numberer.DayOfMonthFactory, dayOfMonthParser),
+		newComponentParser(componentMonth, d.monthFactory(), parse.NewParser),
+		newComponentParser(componentDayOfWeek, d.dayOfWeekFactory(), dayOfWeekParser),
+	)
+	if d.years != nil {
+		var err error
+		if parser, err = parser.WithYears(d.years.Start, d.years.End); err != nil {
+			return nil, err
+		}
+	}
+	for i := range parser {
+		parser[i].withoutMacros = !d.macros
+		parser[i].withoutTimeZone = !d.timeZone
+		parser[i].noSpecificDay = d.noSpecificDay
+		parser[i].days = d.days
+		parser[i].dst = d.dst
+	}
+	return parser, nil
+}
+
+// monthFactory returns the factory for the month component of the dialect
+func (d dialect) monthFactory() numberer.Factory {
+	if d.names {
+		return numberer.MonthFactory
+	}
+	return numberer.Must(numberer.NewFactory("month", 1, 12))
+}
+
+// dayOfWeekFactory returns the factory for the day of week component of the
+// dialect
+func (d dialect) dayOfWeekFactory() numberer.Factory {
+	switch {
+	case d.quartzWeekdays && d.names:
+		return numberer.QuartzDayOfWeekFactory
+	case d.quartzWeekdays:
+		return numberer.Must(numberer.NewFactory("dayOfWeek", 1, 7)).WithOffset(-1)
+	}
+	factory := numberer.DayOfWeekFactory
+	if !d.names {
+		factory = numberer.Must(numberer.NewFactory("dayOfWeek", 0, 6))
+	}
+	if d.sundaySeven {
+		return factory.WithWrappingEnd(7)
+	}
+	return factory.WithWrappingEnd(0)
+}
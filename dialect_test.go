@@ -0,0 +1,346 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []cronparse.Option
+		expression string
+		expected   []string
+	}{
+		{
+			name:       "default",
+			expression: "CRON_TZ=UTC 0 9 * JAN 7",
+			expected: []string{
+				"minute         0",
+				"hour           9",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1",
+				"day of week    0",
+			},
+		},
+		{
+			name:       "default macro",
+			expression: "@daily",
+			expected: []string{
+				"minute         0",
+				"hour           0",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    0 1 2 3 4 5 6",
+			},
+		},
+		{
+			name:       "vixie",
+			opts:       []cronparse.Option{cronparse.Vixie},
+			expression: "*/30 9 1 * 5-7",
+			expected: []string{
+				"minute         0 30",
+				"hour           9",
+				"day of month   1",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    0 5 6",
+			},
+		},
+		{
+			name:       "quartz",
+			opts:       []cronparse.Option{cronparse.Quartz},
+			expression: "0 15 10 ? * 6L",
+			expected: []string{
+				"second         0",
+				"minute         15",
+				"hour           10",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    FRIL",
+			},
+		},
+		{
+			name:       "quartz with years",
+			opts:       []cronparse.Option{cronparse.Quartz, cronparse.WithYears(2020, 2030)},
+			expression: "0 0 12 L * ? 2025",
+			expected: []string{
+				"second         0",
+				"minute         0",
+				"hour           12",
+				"day of month   L",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    0 1 2 3 4 5 6",
+				"year           2025",
+			},
+		},
+		{
+			name:       "aws",
+			opts:       []cronparse.Option{cronparse.AWS},
+			expression: "0 18 ? * MON-FRI 2150",
+			expected: []string{
+				"minute         0",
+				"hour           18",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    1 2 3 4 5",
+				"year           2150",
+			},
+		},
+		{
+			name:       "kubernetes",
+			opts:       []cronparse.Option{cronparse.Kubernetes},
+			expression: "CRON_TZ=Europe/London 0 0 * * SUN",
+			expected: []string{
+				"minute         0",
+				"hour           0",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    0",
+			},
+		},
+		{
+			name:       "quartz specials",
+			opts:       []cronparse.Option{cronparse.WithQuartzSpecials(true)},
+			expression: "0 0 ? * 1#2",
+			expected: []string{
+				"minute         0",
+				"hour           0",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    MON#2",
+			},
+		},
+		{
+			name:       "quartz weekdays",
+			opts:       []cronparse.Option{cronparse.WithQuartzWeekdays(true)},
+			expression: "0 0 * * 1",
+			expected: []string{
+				"minute         0",
+				"hour           0",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    0",
+			},
+		},
+		{
+			name:       "quartz without quartz weekdays",
+			opts:       []cronparse.Option{cronparse.Quartz, cronparse.WithQuartzWeekdays(false)},
+			expression: "0 0 0 ? * 0L",
+			expected: []string{
+				"second         0",
+				"minute         0",
+				"hour           0",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    SUNL",
+			},
+		},
+		{
+			name:       "quartz without no specific day",
+			opts:       []cronparse.Option{cronparse.Quartz, cronparse.WithNoSpecificDay(false)},
+			expression: "0 0 0 * * MON",
+			expected: []string{
+				"second         0",
+				"minute         0",
+				"hour           0",
+				"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+				"month          1 2 3 4 5 6 7 8 9 10 11 12",
+				"day of week    1",
+			},
+		},
+		{
+			name:       "seconds without names",
+			opts:       []cronparse.Option{cronparse.WithSeconds(true), cronparse.WithNames(false)},
+			expression: "0 0 0 1 1 0",
+			expected: []string{
+				"second         0",
+				"minute         0",
+				"hour           0",
+				"day of month   1",
+				"month          1",
+				"day of week    0",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := cronparse.New(test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			res, err := parser.Parse(strings.Fields(test.expression))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(res))
+			for _, res := range res {
+				got = append(got, res.String())
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestNew_ParseFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []cronparse.Option
+		expression string
+	}{
+		{
+			name:       "vixie time zone",
+			opts:       []cronparse.Option{cronparse.Vixie},
+			expression: "CRON_TZ=UTC 0 0 * * *",
+		},
+		{
+			name:       "quartz macro",
+			opts:       []cronparse.Option{cronparse.Quartz},
+			expression: "@daily",
+		},
+		{
+			name:       "robfig sunday seven",
+			opts:       []cronparse.Option{cronparse.Robfig},
+			expression: "0 0 * * 7",
+		},
+		{
+			name:       "without names",
+			opts:       []cronparse.Option{cronparse.WithNames(false)},
+			expression: "0 0 * JAN *",
+		},
+		{
+			name:       "without quartz specials",
+			opts:       []cronparse.Option{cronparse.AWS, cronparse.WithQuartzSpecials(false)},
+			expression: "0 0 L * ? 2020",
+		},
+		{
+			name:       "aws no specific in both days",
+			opts:       []cronparse.Option{cronparse.AWS},
+			expression: "0 0 ? * ? 2020",
+		},
+		{
+			name:       "aws wildcard in both days",
+			opts:       []cronparse.Option{cronparse.AWS},
+			expression: "0 0 * * * 2020",
+		},
+		{
+			name:       "quartz weekdays zero",
+			opts:       []cronparse.Option{cronparse.WithQuartzWeekdays(true)},
+			expression: "0 0 * * 0",
+		},
+		{
+			name:       "without years",
+			opts:       []cronparse.Option{cronparse.AWS, cronparse.WithoutYears()},
+			expression: "0 0 L * ? 2020",
+		},
+		{
+			name:       "aws year out of range",
+			opts:       []cronparse.Option{cronparse.AWS},
+			expression: "0 0 L * ? 2200",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := cronparse.New(test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := parser.Parse(strings.Fields(test.expression)); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestNew_Fails(t *testing.T) {
+	if _, err := cronparse.New(cronparse.WithYears(2030, 2020)); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestNew_MatchesParsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []cronparse.Option
+		expected cronparse.Parser
+	}{
+		{name: "cron", expected: cronparse.CronParser},
+		{name: "seconds", opts: []cronparse.Option{cronparse.WithSeconds(true)}, expected: cronparse.SecondsCronParser},
+		{
+			name:     "quartz",
+			opts:     []cronparse.Option{cronparse.WithSeconds(true), cronparse.WithQuartzSpecials(true), cronparse.WithQuartzWeekdays(true)},
+			expected: cronparse.QuartzParser,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := cronparse.New(test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := make([]string, 0, len(test.expected))
+			for _, componentParser := range test.expected {
+				expected = append(expected, componentParser.Name)
+			}
+			got := make([]string, 0, len(parser))
+			for _, componentParser := range parser {
+				got = append(got, componentParser.Name)
+			}
+			if !reflect.DeepEqual(expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", expected, got)
+			}
+		})
+	}
+}
+
+func TestNew_Policies(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []cronparse.Option
+		days cronparse.DayPolicy
+		dst  cronparse.DSTPolicy
+	}{
+		{name: "default", days: cronparse.DaysVixie, dst: cronparse.DSTVixie},
+		{name: "quartz", opts: []cronparse.Option{cronparse.Quartz}, days: cronparse.DaysAnd, dst: cronparse.DSTVixie},
+		{
+			name: "dst policy",
+			opts: []cronparse.Option{cronparse.WithDSTPolicy(cronparse.DSTSkip)},
+			days: cronparse.DaysVixie,
+			dst:  cronparse.DSTSkip,
+		},
+		{
+			name: "preset then policies",
+			opts: []cronparse.Option{cronparse.Quartz, cronparse.WithDayPolicy(cronparse.DaysVixie), cronparse.WithDSTPolicy(cronparse.DSTRepeat)},
+			days: cronparse.DaysVixie,
+			dst:  cronparse.DSTRepeat,
+		},
+		{
+			name: "policy then preset",
+			opts: []cronparse.Option{cronparse.WithDSTPolicy(cronparse.DSTShift), cronparse.Vixie},
+			days: cronparse.DaysVixie,
+			dst:  cronparse.DSTVixie,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := cronparse.New(append(test.opts, cronparse.WithMacros(true))...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schedule, err := parser.Schedule([]string{"@daily"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if schedule.Days != test.days {
+				t.Fatalf("expected (%s), got (%s)", test.days, schedule.Days)
+			}
+			if schedule.DST != test.dst {
+				t.Fatalf("expected (%s), got (%s)", test.dst, schedule.DST)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package cronparse
+
+import (
+	"sort"
+	"time"
+)
+
+// dstWindow is how far either side of a time we look for the clocks changing,
+// it needs to be longer than the largest daylight saving shift in use
+const dstWindow = 3 * time.Hour
+
+// DSTPolicy decides what a Schedule does with the wall clock times that are
+// skipped (a gap) when the clocks go forward, or repeated (an overlap) when
+// the clocks go back
+type DSTPolicy int
+
+// Policies for handling daylight saving time transitions
+const (
+	// DSTVixie matches the documented behaviour of Vixie cron. Schedules that
+	// run at fixed times are treated as DSTShift, so they run once on the day
+	// of a transition. Schedules with a wildcard minute or hour, a component
+	// that starts with (*) such as (*) or (*/15), follow the wall clock, as
+	// with DSTRepeat. A range such as (0-59) is not a wildcard.
+	DSTVixie DSTPolicy = iota
+	// DSTSkip will not run at times in a gap, and will only run at the first
+	// instance of times in an overlap
+	DSTSkip
+	// DSTShift will run once at the moment the clocks go forward for times in a
+	// gap, and will only run at the first instance of times in an overlap
+	DSTShift
+	// DSTRepeat will not run at times in a gap, and will run at both instances
+	// of times in an overlap
+	DSTRepeat
+)
+
+var dstPolicyNames = map[DSTPolicy]string{
+	DSTVixie:  "vixie",
+	DSTSkip:   "skip",
+	DSTShift:  "shift",
+	DSTRepeat: "repeat",
+}
+
+// String implements fmt.Stringer and returns the name of the policy
+func (d DSTPolicy) String() string {
+	return dstPolicyNames[d]
+}
+
+// instants returns the instants that the schedule runs at for a wall clock
+// time, applying the DST policy of the schedule
+func (s *Schedule) instants(c wallClock, loc *time.Location) []time.Time {
+	instants := c.instants(loc)
+	switch len(instants) {
+	case 0:
+		if s.shiftsGaps() {
+			return []time.Time{gapEnd(c, loc)}
+		}
+	case 2:
+		if !s.repeatsOverlaps() {
+			return instants[:1]
+		}
+	}
+	return instants
+}
+
+func (s *Schedule) shiftsGaps() bool {
+	switch s.DST {
+	case DSTShift:
+		return true
+	case DSTVixie:
+		return !s.hasWildcardTime()
+	}
+	return false
+}
+
+func (s *Schedule) repeatsOverlaps() bool {
+	switch s.DST {
+	case DSTRepeat:
+		return true
+	case DSTVixie:
+		return s.hasWildcardTime()
+	}
+	return false
+}
+
+// hasWildcardTime tells you whether the minute or hour is a wildcard, in the
+// same way as Vixie cron, see isWildcard
+func (s *Schedule) hasWildcardTime() bool {
+	return s.minuteWildcard || s.hourWildcard
+}
+
+// instants returns all of the instants that the wall clock shows this time in
+// a location, there are none in a gap and two in an overlap
+func (w wallClock) instants(loc *time.Location) []time.Time {
+	guess := w.in(loc)
+	utc := w.in(time.UTC)
+	instants := make([]time.Time, 0, 2)
+	for _, probe := range []time.Time{guess.Add(-dstWindow), guess, guess.Add(dstWindow)} {
+		_, offset := probe.Zone()
+		instant := utc.Add(-time.Duration(offset) * time.Second).In(loc)
+		if wallClockOf(instant) != w || containsInstant(instants, instant) {
+			continue
+		}
+		instants = append(instants, instant)
+	}
+	sort.Slice(instants, func(i, j int) bool {
+		return instants[i].Before(instants[j])
+	})
+	return instants
+}
+
+func containsInstant(instants []time.Time, instant time.Time) bool {
+	for _, existing := range instants {
+		if existing.Equal(instant) {
+			return true
+		}
+	}
+	return false
+}
+
+// gapEnd returns the instant that the clocks went forward, skipping over the
+// wall clock time w
+func gapEnd(w wallClock, loc *time.Location) time.Time {
+	utc := w.in(time.UTC)
+	_, before := w.in(loc).Add(-dstWindow).Zone()
+	_, after := w.in(loc).Add(dstWindow).Zone()
+	lo := utc.Add(-time.Duration(after) * time.Second)
+	hi := utc.Add(-time.Duration(before) * time.Second)
+	seconds := sort.Search(int(hi.Sub(lo)/time.Second), func(i int) bool {
+		return !wallClockOf(lo.Add(time.Duration(i) * time.Second).In(loc)).before(w)
+	})
+	return lo.Add(time.Duration(seconds) * time.Second).In(loc)
+}
+
+// overlapAhead returns how far the clocks will go back if t is shortly before
+// they do, and the wall clock times before t will be repeated
+func overlapAhead(t time.Time) time.Duration {
+	_, now := t.Zone()
+	_, later := t.Add(dstWindow).Zone()
+	if later >= now {
+		return 0
+	}
+	return time.Duration(now-later) * time.Second
+}
+
+// overlapBehind returns how far the clocks went back if t is shortly after
+// they did, and the wall clock times after t have already happened
+func overlapBehind(t time.Time) time.Duration {
+	_, now := t.Zone()
+	_, earlier := t.Add(-dstWindow).Zone()
+	if earlier <= now {
+		return 0
+	}
+	return time.Duration(earlier-now) * time.Second
+}
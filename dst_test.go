@@ -0,0 +1,332 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+	_ "time/tzdata" // so the tests don't depend on the zoneinfo of the host
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func mustLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+func TestSchedule_NextDST(t *testing.T) {
+	tests := []struct {
+		name       string
+		location   string
+		expression string
+		policy     cronparse.DSTPolicy
+		from       string
+		expected   []string
+	}{
+		{
+			name:       "berlin gap vixie fixed time runs when the clocks change",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-03-27T03:00:00+01:00",
+			expected:   []string{"2021-03-28T03:00:00+02:00", "2021-03-29T02:30:00+02:00"},
+		},
+		{
+			name:       "berlin gap skip",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTSkip,
+			from:       "2021-03-27T03:00:00+01:00",
+			expected:   []string{"2021-03-29T02:30:00+02:00", "2021-03-30T02:30:00+02:00"},
+		},
+		{
+			name:       "berlin gap shift",
+			location:   "Europe/Berlin",
+			expression: "0,30 2 * * *",
+			policy:     cronparse.DSTShift,
+			from:       "2021-03-27T03:00:00+01:00",
+			expected:   []string{"2021-03-28T03:00:00+02:00", "2021-03-29T02:00:00+02:00"},
+		},
+		{
+			name:       "berlin gap repeat",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-03-27T03:00:00+01:00",
+			expected:   []string{"2021-03-29T02:30:00+02:00"},
+		},
+		{
+			name:       "berlin gap vixie wildcard hour follows the clock",
+			location:   "Europe/Berlin",
+			expression: "30 * * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-03-28T01:00:00+01:00",
+			expected:   []string{"2021-03-28T01:30:00+01:00", "2021-03-28T03:30:00+02:00"},
+		},
+		{
+			name:       "berlin overlap vixie fixed time runs once",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-10-30T03:00:00+02:00",
+			expected:   []string{"2021-10-31T02:30:00+02:00", "2021-11-01T02:30:00+01:00"},
+		},
+		{
+			name:       "berlin overlap skip",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTSkip,
+			from:       "2021-10-30T03:00:00+02:00",
+			expected:   []string{"2021-10-31T02:30:00+02:00", "2021-11-01T02:30:00+01:00"},
+		},
+		{
+			name:       "berlin overlap repeat",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-10-30T03:00:00+02:00",
+			expected: []string{
+				"2021-10-31T02:30:00+02:00",
+				"2021-10-31T02:30:00+01:00",
+				"2021-11-01T02:30:00+01:00",
+			},
+		},
+		{
+			name:       "berlin overlap repeat from within the overlap",
+			location:   "Europe/Berlin",
+			expression: "15,45 2 * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-10-31T02:30:00+02:00",
+			expected: []string{
+				"2021-10-31T02:45:00+02:00",
+				"2021-10-31T02:15:00+01:00",
+				"2021-10-31T02:45:00+01:00",
+			},
+		},
+		{
+			name:       "berlin overlap vixie wildcard hour follows the clock",
+			location:   "Europe/Berlin",
+			expression: "30 * * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-10-31T02:00:00+02:00",
+			expected: []string{
+				"2021-10-31T02:30:00+02:00",
+				"2021-10-31T02:30:00+01:00",
+				"2021-10-31T03:30:00+01:00",
+			},
+		},
+		{
+			name:       "berlin overlap vixie stepped wildcard minute follows the clock",
+			location:   "Europe/Berlin",
+			expression: "*/30 2 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-10-31T02:10:00+02:00",
+			expected: []string{
+				"2021-10-31T02:30:00+02:00",
+				"2021-10-31T02:00:00+01:00",
+				"2021-10-31T02:30:00+01:00",
+			},
+		},
+		{
+			name:       "berlin overlap vixie full range is a fixed time",
+			location:   "Europe/Berlin",
+			expression: "0-59/30 2 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-10-31T02:10:00+02:00",
+			expected:   []string{"2021-10-31T02:30:00+02:00", "2021-11-01T02:00:00+01:00"},
+		},
+		{
+			name:       "berlin gap vixie stepped wildcard minute is skipped",
+			location:   "Europe/Berlin",
+			expression: "*/30 2 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-03-28T00:00:00+01:00",
+			expected:   []string{"2021-03-29T02:00:00+02:00"},
+		},
+		{
+			name:       "london is not affected at half past two",
+			location:   "Europe/London",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-03-27T03:00:00Z",
+			expected:   []string{"2021-03-28T02:30:00+01:00", "2021-03-29T02:30:00+01:00"},
+		},
+		{
+			name:       "london gap vixie",
+			location:   "Europe/London",
+			expression: "30 1 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-03-27T03:00:00Z",
+			expected:   []string{"2021-03-28T02:00:00+01:00", "2021-03-29T01:30:00+01:00"},
+		},
+		{
+			name:       "new york overlap repeat",
+			location:   "America/New_York",
+			expression: "30 1 * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-11-07T00:00:00-04:00",
+			expected: []string{
+				"2021-11-07T01:30:00-04:00",
+				"2021-11-07T01:30:00-05:00",
+				"2021-11-08T01:30:00-05:00",
+			},
+		},
+		{
+			name:       "lord howe half hour gap shift",
+			location:   "Australia/Lord_Howe",
+			expression: "15 2 * * *",
+			policy:     cronparse.DSTShift,
+			from:       "2021-10-02T12:00:00+10:30",
+			expected:   []string{"2021-10-03T02:30:00+11:00", "2021-10-04T02:15:00+11:00"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule := mustSchedule(t, test.expression)
+			schedule.Location = mustLocation(t, test.location)
+			schedule.DST = test.policy
+			got := make([]string, 0, len(test.expected))
+			for next := mustTime(t, test.from); len(got) < len(test.expected); {
+				next = schedule.Next(next)
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_PrevDST(t *testing.T) {
+	tests := []struct {
+		name       string
+		location   string
+		expression string
+		policy     cronparse.DSTPolicy
+		from       string
+		expected   []string
+	}{
+		{
+			name:       "berlin gap vixie fixed time runs when the clocks change",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-03-29T00:00:00+02:00",
+			expected:   []string{"2021-03-28T03:00:00+02:00", "2021-03-27T02:30:00+01:00"},
+		},
+		{
+			name:       "berlin gap skip",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTSkip,
+			from:       "2021-03-29T00:00:00+02:00",
+			expected:   []string{"2021-03-27T02:30:00+01:00"},
+		},
+		{
+			name:       "berlin overlap repeat",
+			location:   "Europe/Berlin",
+			expression: "30 2 * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-11-01T00:00:00+01:00",
+			expected: []string{
+				"2021-10-31T02:30:00+01:00",
+				"2021-10-31T02:30:00+02:00",
+				"2021-10-30T02:30:00+02:00",
+			},
+		},
+		{
+			name:       "berlin overlap repeat from within the overlap",
+			location:   "Europe/Berlin",
+			expression: "15,45 2 * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-10-31T02:30:00+01:00",
+			expected: []string{
+				"2021-10-31T02:15:00+01:00",
+				"2021-10-31T02:45:00+02:00",
+				"2021-10-31T02:15:00+02:00",
+			},
+		},
+		{
+			name:       "berlin overlap repeat from a second instance",
+			location:   "Europe/Berlin",
+			expression: "15,45 2 * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-10-31T02:45:00+01:00",
+			expected: []string{
+				"2021-10-31T02:15:00+01:00",
+				"2021-10-31T02:45:00+02:00",
+				"2021-10-31T02:15:00+02:00",
+			},
+		},
+		{
+			name:       "london overlap vixie wildcard from a second instance",
+			location:   "Europe/London",
+			expression: "*/20 * * * *",
+			policy:     cronparse.DSTVixie,
+			from:       "2021-10-31T01:40:00Z",
+			expected: []string{
+				"2021-10-31T01:20:00Z",
+				"2021-10-31T01:00:00Z",
+				"2021-10-31T01:40:00+01:00",
+				"2021-10-31T01:20:00+01:00",
+			},
+		},
+		{
+			name:       "lord howe half hour overlap repeat from a second instance",
+			location:   "Australia/Lord_Howe",
+			expression: "*/15 * * * *",
+			policy:     cronparse.DSTRepeat,
+			from:       "2021-04-04T01:45:00+10:30",
+			expected: []string{
+				"2021-04-04T01:30:00+10:30",
+				"2021-04-04T01:45:00+11:00",
+				"2021-04-04T01:30:00+11:00",
+				"2021-04-04T01:15:00+11:00",
+			},
+		},
+		{
+			name:       "berlin overlap skip from within the overlap",
+			location:   "Europe/Berlin",
+			expression: "45 2 * * *",
+			policy:     cronparse.DSTSkip,
+			from:       "2021-10-31T02:30:00+01:00",
+			expected:   []string{"2021-10-31T02:45:00+02:00", "2021-10-30T02:45:00+02:00"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule := mustSchedule(t, test.expression)
+			schedule.Location = mustLocation(t, test.location)
+			schedule.DST = test.policy
+			got := make([]string, 0, len(test.expected))
+			for prev := mustTime(t, test.from); len(got) < len(test.expected); {
+				prev = schedule.Prev(prev)
+				got = append(got, prev.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextUsesLocation(t *testing.T) {
+	schedule := mustSchedule(t, "0 9 * * *")
+	schedule.Location = mustLocation(t, "Asia/Tokyo")
+	got := schedule.Next(mustTime(t, "2019-12-31T23:00:00Z"))
+	expected := "2020-01-01T09:00:00+09:00"
+	if got.Format(time.RFC3339) != expected {
+		t.Fatalf("expected (%s), got (%s)", expected, got.Format(time.RFC3339))
+	}
+}
+
+func TestDSTPolicy_String(t *testing.T) {
+	expected := "repeat"
+	if got := cronparse.DSTRepeat.String(); got != expected {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+}
@@ -0,0 +1,67 @@
+package cronparse
+
+import (
+	"errors"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+)
+
+// Position is where a problem is within a component of an expression, Field
+// is the name of the component, and Offset and Length are in bytes within it
+type Position = cronerr.Position
+
+// SyntaxError is returned for text in a component that isn't valid syntax,
+// e.g. (a) in (5a)
+type SyntaxError = cronerr.SyntaxError
+
+// RangeError is returned for a value in a component that is outside of the
+// values that the component allows, e.g. (32) in the day of month
+type RangeError = cronerr.RangeError
+
+// OrderError is returned for a range whose start is after its end, e.g.
+// (FRI-MON), when the parser doesn't allow ranges to wrap around, see
+// WithWrapAround
+type OrderError = cronerr.OrderError
+
+// StepError is returned for a step that is less than one, or more than the
+// number of values in the component, e.g. (0) in (*/0)
+type StepError = cronerr.StepError
+
+// Errors is returned by parsers created with WithAllErrors, it lists all of
+// the errors in an expression in the order that they appear. errors.As,
+// Suggestions and Fix don't look inside it, so range over it to inspect or
+// fix each of the errors.
+type Errors = cronerr.Errors
+
+// Suggestion is a possible fix for an error, its Message describes the fix,
+// and Fix will apply it to the components of the expression
+type Suggestion = cronerr.Suggestion
+
+// Suggestions returns the possible fixes for an error, such as 0 for 24 in the
+// hour component, or MON for MONDAY. It returns none for Errors, whose errors
+// each have their own suggestions.
+func Suggestions(err error) []Suggestion {
+	return cronerr.Suggested(err)
+}
+
+// Fix will return a copy of the components with the suggestion for err
+// applied, the components are returned as they are if err doesn't have a
+// position within them, as with Errors rather than one of its errors
+func Fix(components []string, err error, suggestion Suggestion) []string {
+	var located cronerr.Located
+	if !errors.As(err, &located) {
+		return components
+	}
+	position := located.Where()
+	if position.Field == "" || position.Index >= len(components) {
+		return components
+	}
+	component := components[position.Index]
+	end := suggestion.Offset + suggestion.Length
+	if suggestion.Offset < 0 || end > len(component) {
+		return components
+	}
+	fixed := append([]string(nil), components...)
+	fixed[position.Index] = component[:suggestion.Offset] + suggestion.Replacement + component[end:]
+	return fixed
+}
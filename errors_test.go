@@ -0,0 +1,469 @@
+package cronparse_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alistairjudson/cronparse"
+	"github.com/alistairjudson/cronparse/field"
+)
+
+func TestParser_ParseErrorPositions(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+		target     interface{}
+		expected   cronparse.Position
+	}{
+		{
+			name:       "syntax",
+			parser:     cronparse.CronParser,
+			expression: "1,5a * * * *",
+			target:     new(*cronparse.SyntaxError),
+			expected:   cronparse.Position{Field: "minute", Index: 0, Offset: 3, Length: 1},
+		},
+		{
+			name:       "unknown name",
+			parser:     cronparse.CronParser,
+			expression: "* * * JAN-FOO *",
+			target:     new(*cronparse.SyntaxError),
+			expected:   cronparse.Position{Field: "month", Index: 3, Offset: 4, Length: 3},
+		},
+		{
+			name:       "number out of range",
+			parser:     cronparse.CronParser,
+			expression: "* * 1,32 * *",
+			target:     new(*cronparse.RangeError),
+			expected:   cronparse.Position{Field: "day of month", Index: 2, Offset: 2, Length: 2},
+		},
+		{
+			name:       "end of range out of range",
+			parser:     cronparse.CronParser,
+			expression: "* 20-24 * * *",
+			target:     new(*cronparse.RangeError),
+			expected:   cronparse.Position{Field: "hour", Index: 1, Offset: 3, Length: 2},
+		},
+		{
+			name:       "backwards range",
+			parser:     cronparse.CronParser,
+			expression: "* 1,20-10 * * *",
+			target:     new(*cronparse.OrderError),
+			expected:   cronparse.Position{Field: "hour", Index: 1, Offset: 2, Length: 5},
+		},
+		{
+			name:       "step",
+			parser:     cronparse.CronParser,
+			expression: "*/0 * * * *",
+			target:     new(*cronparse.StepError),
+			expected:   cronparse.Position{Field: "minute", Index: 0, Offset: 2, Length: 1},
+		},
+		{
+			name:       "quartz week",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 ? * MON#6",
+			target:     new(*cronparse.RangeError),
+			expected:   cronparse.Position{Field: "day of week", Index: 5, Offset: 4, Length: 1},
+		},
+		{
+			name:       "quartz no specific in both days",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 ? * ?",
+			target:     new(*cronparse.SyntaxError),
+			expected:   cronparse.Position{Field: "day of week", Index: 5, Offset: 0, Length: 1},
+		},
+		{
+			name:       "quartz no specific in neither day",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 1 * MON",
+			target:     new(*cronparse.SyntaxError),
+			expected:   cronparse.Position{Field: "day of week", Index: 5, Offset: 0, Length: 3},
+		},
+		{
+			name:       "quartz wildcard in both days",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 * * *",
+			target:     new(*cronparse.SyntaxError),
+			expected:   cronparse.Position{Field: "day of month", Index: 3, Offset: 0, Length: 1},
+		},
+		{
+			name:       "after a time zone",
+			parser:     cronparse.CronParser,
+			expression: "CRON_TZ=UTC * 24 * * *",
+			target:     new(*cronparse.RangeError),
+			expected:   cronparse.Position{Field: "hour", Index: 2, Offset: 0, Length: 2},
+		},
+		{
+			name:       "special character in a standard field",
+			parser:     cronparse.CronParser,
+			expression: "* * 15W * *",
+			target:     new(*cronparse.SyntaxError),
+			expected:   cronparse.Position{Field: "day of month", Index: 2, Offset: 2, Length: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.parser.Parse(strings.Fields(test.expression))
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if !errors.As(err, test.target) {
+				t.Fatalf("expected error to be a (%T), got (%T)", test.target, err)
+			}
+			var got cronparse.Position
+			switch target := test.target.(type) {
+			case **cronparse.SyntaxError:
+				got = (*target).Position
+			case **cronparse.RangeError:
+				got = (*target).Position
+			case **cronparse.StepError:
+				got = (*target).Position
+			case **cronparse.OrderError:
+				got = (*target).Position
+			}
+			got.Allowed = ""
+			if got != test.expected {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParser_ParseRangeError(t *testing.T) {
+	_, err := cronparse.CronParser.Parse([]string{"*", "*", "32", "*", "*"})
+	var rangeErr *cronparse.RangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatalf("expected a range error, got (%s)", err)
+	}
+	if rangeErr.Value != "32" || rangeErr.Min != 1 || rangeErr.Max != 31 {
+		t.Fatalf("expected (32) in range (1-31), got (%+v)", rangeErr)
+	}
+	if rangeErr.Allowed != "a number (1-31) or (*)" {
+		t.Fatalf("expected (a number (1-31) or (*)), got (%s)", rangeErr.Allowed)
+	}
+	expected := "(day of month): number (32) must be in range (1-31)"
+	if err.Error() != expected {
+		t.Fatalf("expected (%s), got (%s)", expected, err)
+	}
+}
+
+func TestParser_WithAllErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+		expected   []string
+		positions  []cronparse.Position
+	}{
+		{
+			name:       "every component",
+			parser:     cronparse.CronParser.WithAllErrors(),
+			expression: "60 5a,*/0 32 * MON-FOO",
+			expected: []string{
+				"(minute): number (60) must be in range (0-59)",
+				"(hour): (a) is unexpected after a number, expected (,), (-) or (/)",
+				"(hour): step (0) must be in range (1-24)",
+				"(day of month): number (32) must be in range (1-31)",
+				"(day of week): (FOO) is unexpected as a name, expected a number (0-7) or a name (SUN-SAT)",
+			},
+			positions: []cronparse.Position{
+				{Field: "minute", Index: 0, Offset: 0, Length: 2},
+				{Field: "hour", Index: 1, Offset: 1, Length: 1},
+				{Field: "hour", Index: 1, Offset: 5, Length: 1},
+				{Field: "day of month", Index: 2, Offset: 0, Length: 2},
+				{Field: "day of week", Index: 4, Offset: 4, Length: 3},
+			},
+		},
+		{
+			name:       "with years and wrap around",
+			parser:     withYears(t, cronparse.CronParser.WithAllErrors().WithWrapAround(), 2000, 2010),
+			expression: "22-2 1,,2 * * * 1999",
+			expected: []string{
+				"(hour): (,) is unexpected after (,), expected (*) or a number",
+				"(year): number (1999) must be in range (2000-2010)",
+			},
+			positions: []cronparse.Position{
+				{Field: "hour", Index: 1, Offset: 2, Length: 1},
+				{Field: "year", Index: 5, Offset: 0, Length: 4},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.parser.Parse(strings.Fields(test.expression))
+			errs, ok := err.(cronparse.Errors)
+			if !ok {
+				t.Fatalf("expected errors, got (%v)", err)
+			}
+			got := make([]string, 0, len(errs))
+			positions := make([]cronparse.Position, 0, len(errs))
+			for _, err := range errs {
+				got = append(got, err.Error())
+				var located interface{ Where() cronparse.Position }
+				if errors.As(err, &located) {
+					position := located.Where()
+					position.Allowed = ""
+					positions = append(positions, position)
+				}
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+			if !reflect.DeepEqual(test.positions, positions) {
+				t.Fatalf("expected (%+v), got (%+v)", test.positions, positions)
+			}
+		})
+	}
+}
+
+func withYears(t *testing.T, parser cronparse.Parser, start, end int) cronparse.Parser {
+	t.Helper()
+	parser, err := parser.WithYears(start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parser
+}
+
+func TestFieldComponent_Errors(t *testing.T) {
+	hour, err := field.New("hour", 0, 23)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parser := cronparse.Parser{
+		cronparse.CronParser[0],
+		cronparse.FieldComponent(hour.With(field.Step())),
+	}.WithAllErrors()
+	_, err = parser.Parse([]string{"0", "24,*/0"})
+	var errs cronparse.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected errors, got (%v)", err)
+	}
+	expected := []cronparse.Position{
+		{Field: "hour", Index: 1, Allowed: "a number (0-23) or (*)", Offset: 0, Length: 2},
+		{Field: "hour", Index: 1, Allowed: "a number (0-23) or (*)", Offset: 5, Length: 1},
+	}
+	got := make([]cronparse.Position, 0, len(errs))
+	for _, err := range errs {
+		var located interface{ Where() cronparse.Position }
+		if !errors.As(err, &located) {
+			t.Fatalf("expected a located error, got (%v)", err)
+		}
+		got = append(got, located.Where())
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
+
+func TestParser_WithAllErrorsSucceeds(t *testing.T) {
+	expression := []string{"*/15", "0", "1,15", "*", "1-5"}
+	expected, err := cronparse.CronParser.Parse(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := cronparse.CronParser.WithAllErrors().Parse(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
+
+func TestSuggestions(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+		expected   []string
+		fixed      string
+	}{
+		{
+			name:       "quartz no specific in both days",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 ? * ?",
+			expected:   []string{"did you mean (*)?"},
+			fixed:      "0 0 0 ? * *",
+		},
+		{
+			name:       "quartz wildcard in both days",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 * * *",
+			expected:   []string{"did you mean (?)?"},
+			fixed:      "0 0 0 ? * *",
+		},
+		{
+			name:       "sixty minutes",
+			parser:     cronparse.CronParser,
+			expression: "60 * * * *",
+			expected:   []string{"did you mean 0 (the start of the hour)?"},
+			fixed:      "0 * * * *",
+		},
+		{
+			name:       "twenty four hours",
+			parser:     cronparse.CronParser,
+			expression: "0 1,24 * * *",
+			expected:   []string{"did you mean 0 (midnight)?"},
+			fixed:      "0 1,0 * * *",
+		},
+		{
+			name:       "end of a range of hours",
+			parser:     cronparse.CronParser,
+			expression: "0 9-24 * * *",
+			expected:   []string{"did you mean 23?"},
+			fixed:      "0 9-23 * * *",
+		},
+		{
+			name:       "day of month zero",
+			parser:     cronparse.CronParser,
+			expression: "0 0 0 * *",
+			expected:   []string{"did you mean 1?"},
+			fixed:      "0 0 1 * *",
+		},
+		{
+			name:       "month zero",
+			parser:     cronparse.CronParser,
+			expression: "0 0 1 0 *",
+			expected:   []string{"did you mean 1 (JAN)?"},
+			fixed:      "0 0 1 1 *",
+		},
+		{
+			name:       "full name of a day",
+			parser:     cronparse.CronParser,
+			expression: "0 0 * * SUN,Monday-FRI",
+			expected:   []string{"did you mean MON?"},
+			fixed:      "0 0 * * SUN,MON-FRI",
+		},
+		{
+			name:       "missing step",
+			parser:     cronparse.CronParser,
+			expression: "*/ * * * *",
+			expected:   []string{"remove the (/), or add a step after it"},
+			fixed:      "* * * * *",
+		},
+		{
+			name:       "quartz no specific",
+			parser:     cronparse.CronParser,
+			expression: "0 0 ? * MON",
+			expected:   []string{"(?) is only allowed in the day of month and day of week of Quartz expressions, did you mean (*)?"},
+			fixed:      "0 0 * * MON",
+		},
+		{
+			name:       "quartz no specific in the minute",
+			parser:     cronparse.QuartzParser,
+			expression: "0 ? 0 ? * MON",
+			expected:   []string{"(?) is only allowed in the day of month and day of week of Quartz expressions, did you mean (*)?"},
+			fixed:      "0 * 0 ? * MON",
+		},
+		{
+			name:       "quartz sunday",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 ? * 0",
+			expected:   []string{"did you mean 1 (SUN)?"},
+			fixed:      "0 0 0 ? * 1",
+		},
+		{
+			name:       "after a time zone",
+			parser:     cronparse.CronParser,
+			expression: "CRON_TZ=UTC 0 24 * * *",
+			expected:   []string{"did you mean 0 (midnight)?"},
+			fixed:      "CRON_TZ=UTC 0 0 * * *",
+		},
+		{
+			name:       "backwards range",
+			parser:     cronparse.CronParser,
+			expression: "0 1,22-2 * * *",
+			expected:   []string{"did you mean 22-23,0-2? or allow ranges that wrap around"},
+			fixed:      "0 1,22-23,0-2 * * *",
+		},
+		{
+			name:       "backwards range from the end",
+			parser:     cronparse.CronParser,
+			expression: "0 23-0 * * *",
+			expected:   []string{"did you mean 23,0? or allow ranges that wrap around"},
+			fixed:      "0 23,0 * * *",
+		},
+		{
+			name:       "backwards range of names",
+			parser:     cronparse.CronParser,
+			expression: "0 0 * * FRI-MON",
+			expected:   []string{"did you mean FRI-SAT,SUN-MON? or allow ranges that wrap around"},
+			fixed:      "0 0 * * FRI-SAT,SUN-MON",
+		},
+		{
+			name:       "backwards range with a step",
+			parser:     cronparse.CronParser,
+			expression: "0 22-2/2 * * *",
+			expected:   []string{},
+			fixed:      "0 22-2/2 * * *",
+		},
+		{
+			name:       "no suggestion",
+			parser:     cronparse.CronParser,
+			expression: "0 0 32 * *",
+			expected:   []string{},
+			fixed:      "0 0 32 * *",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			components := strings.Fields(test.expression)
+			_, err := test.parser.Parse(components)
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			suggestions := cronparse.Suggestions(err)
+			got := make([]string, 0, len(suggestions))
+			for _, suggestion := range suggestions {
+				got = append(got, suggestion.Message)
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+			fixed := components
+			if len(suggestions) > 0 {
+				fixed = cronparse.Fix(components, err, suggestions[0])
+			}
+			if got := strings.Join(fixed, " "); got != test.fixed {
+				t.Fatalf("expected (%s), got (%s)", test.fixed, got)
+			}
+			if _, err := test.parser.Parse(fixed); len(suggestions) > 0 && err != nil {
+				t.Fatalf("expected the fix to parse, got (%s)", err)
+			}
+		})
+	}
+}
+
+func TestFix_WithoutPosition(t *testing.T) {
+	components := []string{"*"}
+	suggestion := cronparse.Suggestion{Replacement: "0"}
+	got := cronparse.Fix(components, errors.New("an error"), suggestion)
+	if !reflect.DeepEqual(components, got) {
+		t.Fatalf("expected (%+v), got (%+v)", components, got)
+	}
+}
+
+func TestSuggestions_Errors(t *testing.T) {
+	components := []string{"60", "24", "*", "*", "*"}
+	_, err := cronparse.CronParser.WithAllErrors().Parse(components)
+	var errs cronparse.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected errors, got (%v)", err)
+	}
+	if got := cronparse.Suggestions(err); len(got) != 0 {
+		t.Fatalf("expected no suggestions, got (%+v)", got)
+	}
+	fixed := components
+	for _, err := range errs {
+		for _, suggestion := range cronparse.Suggestions(err) {
+			fixed = cronparse.Fix(fixed, err, suggestion)
+		}
+	}
+	expected := []string{"0", "0", "*", "*", "*"}
+	if !reflect.DeepEqual(expected, fixed) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, fixed)
+	}
+}
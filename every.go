@@ -0,0 +1,43 @@
+package cronparse
+
+import (
+	"math/big"
+	"time"
+)
+
+// nextInterval returns the first time strictly after t that an every schedule
+// runs at
+func (s *Schedule) nextInterval(t time.Time) time.Time {
+	if s.Interval <= 0 {
+		return time.Time{}
+	}
+	t = t.In(s.location(t))
+	return t.Add(s.Interval - s.sinceRun(t))
+}
+
+// prevInterval returns the last time strictly before t that an every schedule
+// ran at
+func (s *Schedule) prevInterval(t time.Time) time.Time {
+	if s.Interval <= 0 {
+		return time.Time{}
+	}
+	t = t.In(s.location(t))
+	since := s.sinceRun(t)
+	if since == 0 {
+		since = s.Interval
+	}
+	return t.Add(-since)
+}
+
+// sinceRun returns how long it has been since the last run of the schedule,
+// at or before t. The time since the anchor is calculated with big numbers,
+// as it can overflow a time.Duration.
+func (s *Schedule) sinceRun(t time.Time) time.Duration {
+	elapsed := new(big.Int).Sub(unixNano(t), unixNano(s.Anchor))
+	return time.Duration(elapsed.Mod(elapsed, big.NewInt(int64(s.Interval))).Int64())
+}
+
+func unixNano(t time.Time) *big.Int {
+	nanos := new(big.Int).Mul(big.NewInt(t.Unix()), big.NewInt(int64(time.Second)))
+	return nanos.Add(nanos, big.NewInt(int64(t.Nanosecond())))
+}
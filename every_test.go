@@ -0,0 +1,178 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestParser_ScheduleEvery(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression []string
+		expected   time.Duration
+	}{
+		{
+			name:       "separate components",
+			expression: []string{"@every", "1h30m"},
+			expected:   90 * time.Minute,
+		},
+		{
+			name:       "single component",
+			expression: []string{"@every 15s"},
+			expected:   15 * time.Second,
+		},
+		{
+			name:       "with a time zone",
+			expression: []string{"TZ=UTC", "@every", "24h"},
+			expected:   24 * time.Hour,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parsed, err := cronparse.CronParser.Parse(test.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(parsed) != 0 {
+				t.Fatalf("expected no components, got (%+v)", parsed)
+			}
+			schedule, err := cronparse.CronParser.Schedule(test.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if schedule.Kind != cronparse.ScheduleKindEvery {
+				t.Fatalf("expected kind (%s), got (%s)", cronparse.ScheduleKindEvery, schedule.Kind)
+			}
+			if schedule.Interval != test.expected {
+				t.Fatalf("expected interval (%s), got (%s)", test.expected, schedule.Interval)
+			}
+		})
+	}
+}
+
+func TestParser_ScheduleEveryFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression []string
+	}{
+		{
+			name:       "missing interval",
+			expression: []string{"@every"},
+		},
+		{
+			name:       "invalid interval",
+			expression: []string{"@every", "fortnight"},
+		},
+		{
+			name:       "zero interval",
+			expression: []string{"@every", "0s"},
+		},
+		{
+			name:       "negative interval",
+			expression: []string{"@every", "-1h"},
+		},
+		{
+			name:       "argument after macro",
+			expression: []string{"@daily", "1h"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := cronparse.CronParser.Schedule(test.expression); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestSchedule_NextEvery(t *testing.T) {
+	tests := []struct {
+		name     string
+		interval time.Duration
+		anchor   string
+		from     string
+		expected []string
+	}{
+		{
+			name:     "aligned to the zero time",
+			interval: 90 * time.Minute,
+			from:     "2020-01-01T00:10:00Z",
+			expected: []string{"2020-01-01T01:30:00Z", "2020-01-01T03:00:00Z"},
+		},
+		{
+			name:     "anchored after from",
+			interval: time.Hour,
+			anchor:   "2020-01-01T00:20:00Z",
+			from:     "2019-12-31T00:00:00Z",
+			expected: []string{"2019-12-31T00:20:00Z", "2019-12-31T01:20:00Z"},
+		},
+		{
+			name:     "anchored before from",
+			interval: 40 * time.Minute,
+			anchor:   "2020-01-01T00:20:00Z",
+			from:     "2020-01-01T01:00:00Z",
+			expected: []string{"2020-01-01T01:40:00Z", "2020-01-01T02:20:00Z"},
+		},
+		{
+			name:     "on a run",
+			interval: time.Hour,
+			anchor:   "2020-01-01T00:20:00Z",
+			from:     "2020-01-01T00:20:00Z",
+			expected: []string{"2020-01-01T01:20:00Z"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule := &cronparse.Schedule{
+				Kind:     cronparse.ScheduleKindEvery,
+				Interval: test.interval,
+			}
+			if test.anchor != "" {
+				schedule.Anchor = mustTime(t, test.anchor)
+			}
+			got := make([]string, 0, len(test.expected))
+			for next := mustTime(t, test.from); len(got) < len(test.expected); {
+				next = schedule.Next(next)
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_PrevEvery(t *testing.T) {
+	schedule := &cronparse.Schedule{
+		Kind:     cronparse.ScheduleKindEvery,
+		Interval: 45 * time.Minute,
+		Anchor:   mustTime(t, "2020-01-01T00:00:00Z"),
+	}
+	expected := []string{"2020-01-01T00:45:00Z", "2020-01-01T00:00:00Z", "2019-12-31T23:15:00Z"}
+	got := make([]string, 0, len(expected))
+	for prev := mustTime(t, "2020-01-01T01:30:00Z"); len(got) < len(expected); {
+		prev = schedule.Prev(prev)
+		got = append(got, prev.Format(time.RFC3339))
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
+
+func TestSchedule_BetweenEvery(t *testing.T) {
+	schedule, err := cronparse.CronParser.Schedule([]string{"@every", "20m"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs := schedule.Between(mustTime(t, "2020-01-01T00:00:00Z"), mustTime(t, "2020-01-01T01:00:00Z"))
+	count := 0
+	for _, ok := runs.Next(); ok; _, ok = runs.Next() {
+		count++
+	}
+	if count != 3 {
+		t.Fatalf("expected 3 runs, got (%d)", count)
+	}
+}
@@ -0,0 +1,303 @@
+package cronparse
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Matches tells you whether the schedule runs at t, to the nanosecond, so a
+// cron schedule only matches the start of a second. The clocks changing are
+// taken into account in the same way as they are by Next, a reboot schedule
+// never matches.
+func (s *Schedule) Matches(t time.Time) bool {
+	if s.Kind == ScheduleKindReboot {
+		return false
+	}
+	return s.Next(t.Add(-time.Nanosecond)).Equal(t)
+}
+
+// Explanation is a type that explains whether a Schedule runs at a time, and
+// which parts of the expression decided it
+type Explanation struct {
+	// Time is the time that is explained, in the location of the schedule, or
+	// with the offset from before the clocks went forward for a time that they
+	// went forward over
+	Time time.Time
+	// Matches tells you whether the schedule runs at Time, or for a time that
+	// the clocks went forward over, whether it runs in place of it
+	Matches bool
+	// Fields explain each of the components of a cron schedule, in the order
+	// that they were parsed, they are empty for the other kinds of schedule
+	Fields []FieldExplanation
+	// Days is the DayPolicy of the schedule, and EitherDay tells you whether
+	// it ran on the days that match either the day of month or the day of
+	// week, rather than both of them
+	Days      DayPolicy
+	EitherDay bool
+	// Notes explain the reasons that the schedule does or doesn't run at Time
+	// that the fields don't show, such as the clocks changing
+	Notes []string
+}
+
+// FieldExplanation is a type that explains whether a component of a schedule
+// matched a time
+type FieldExplanation struct {
+	// Name is the name of the component, and Value is the value of Time for
+	// it, the day of week is numbered in the same way as the component, so
+	// sunday is 1 for Quartz
+	Name  string
+	Value int
+	// Matches tells you whether the component matched Value
+	Matches bool
+	// Component is the text of the component, or of what its macro expands to
+	Component string
+	// Item is the item of the component, one of the parts between its commas,
+	// that matched Value, and Offset is the byte offset of it in Component.
+	// Item is empty if the component didn't match, or if the schedule wasn't
+	// created by a Parser and the component has more than one item.
+	Item   string
+	Offset int
+}
+
+// Explain will explain whether the schedule runs at t, by showing for each of
+// the components whether it matched the time, which item of it was
+// responsible, and how the day of month and day of week were combined.
+func (s *Schedule) Explain(t time.Time) Explanation {
+	t = t.In(s.location(t))
+	explanation := Explanation{Time: t, Matches: s.Matches(t), Days: s.Days}
+	switch s.Kind {
+	case ScheduleKindReboot:
+		explanation.Notes = append(explanation.Notes, "the schedule runs when the system starts, not at a time")
+		return explanation
+	case ScheduleKindEvery:
+		explanation.Notes = append(explanation.Notes, fmt.Sprintf(
+			"the schedule runs every (%s) from (%s)",
+			s.Interval,
+			s.Anchor.Format(time.RFC3339),
+		))
+		return explanation
+	}
+	fields := s.explainFields(&explanation, wallClockOf(t))
+	switch {
+	case fields && !explanation.Matches:
+		explanation.Notes = append(explanation.Notes, fmt.Sprintf(
+			"the clocks go back over this time, and the (%s) DST policy doesn't run at this instance of it",
+			s.DST,
+		))
+	case !fields && explanation.Matches:
+		explanation.Notes = append(explanation.Notes, fmt.Sprintf(
+			"the clocks went forward over a time that the schedule runs at, and the (%s) DST policy runs at the end of the gap instead",
+			s.DST,
+		))
+	}
+	return explanation
+}
+
+// ExplainDate will explain whether the schedule runs at a wall clock time in
+// a location, as Explain does, but with the time as it was written. time.Date
+// moves a time that the clocks go forward over on by the length of the gap,
+// ExplainDate explains the time that was skipped instead, and notes whether
+// the DST policy ran the schedule at the end of the gap or skipped it. The
+// location of the schedule is used in place of loc if it has one.
+func (s *Schedule) ExplainDate(year int, month time.Month, day, hour, min, sec, nsec int, loc *time.Location) Explanation {
+	if s.Location != nil {
+		loc = s.Location
+	}
+	c := wallClockOf(time.Date(year, month, day, hour, min, sec, 0, time.UTC))
+	if s.Kind != ScheduleKindCron || len(c.instants(loc)) > 0 {
+		return s.Explain(time.Date(year, month, day, hour, min, sec, nsec, loc))
+	}
+	// the time doesn't exist, so it is shown with the offset from before the
+	// clocks went forward, which keeps it as it was written
+	end := gapEnd(c, loc)
+	name, offset := end.Add(-time.Nanosecond).Zone()
+	explanation := Explanation{
+		Time: time.Date(year, month, day, hour, min, sec, nsec, time.FixedZone(name, offset)),
+		Days: s.Days,
+	}
+	note := "the clocks went forward over this time"
+	switch {
+	case !s.explainFields(&explanation, c):
+		note += ", so it doesn't exist"
+	case s.shiftsGaps():
+		explanation.Matches = true
+		note += fmt.Sprintf(", so it runs at (%s) instead (%s: shifted to the end of the gap)", end.Format("15:04:05 MST"), s.DST)
+	default:
+		note += fmt.Sprintf(", so it doesn't run (%s: %s)", s.DST, s.skipReason())
+	}
+	explanation.Notes = append(explanation.Notes, note)
+	return explanation
+}
+
+// skipReason describes why the DST policy skips the times in a gap, DSTVixie
+// only skips them when the minute or hour is a wildcard
+func (s *Schedule) skipReason() string {
+	if s.DST == DSTVixie && s.hasWildcardTime() {
+		return "skipped, as the minute or hour is a wildcard"
+	}
+	return "skipped"
+}
+
+// explainFields will explain each of the components for a wall clock time,
+// and how the days were combined, and tells you whether they all matched it
+func (s *Schedule) explainFields(explanation *Explanation, c wallClock) bool {
+	explanation.EitherDay = s.EitherDay()
+	fields := true
+	hasSecond := false
+	for _, component := range s.components {
+		field := s.explainComponent(component, c)
+		explanation.Fields = append(explanation.Fields, field)
+		switch field.Name {
+		case componentDayOfMonth, componentDayOfWeek:
+			// the days are combined by the day policy below
+		default:
+			fields = fields && field.Matches
+		}
+		hasSecond = hasSecond || field.Name == componentSecond
+	}
+	fields = fields && s.dayMatches(c.year, c.month, c.day)
+	if !hasSecond && c.second != 0 {
+		fields = false
+		explanation.Notes = append(explanation.Notes, "the schedule has no second component, so it only runs at the start of a minute")
+	}
+	if explanation.Time.Nanosecond() != 0 {
+		fields = false
+		explanation.Notes = append(explanation.Notes, "the schedule only runs at the start of a second")
+	}
+	return fields
+}
+
+// explainComponent will explain whether a component matches a wall clock time
+func (s *Schedule) explainComponent(component ParsedComponent, c wallClock) FieldExplanation {
+	field := FieldExplanation{Name: component.Name, Component: component.Text}
+	matches := func(parsed ParsedComponent) bool {
+		return parsed.Set.Contains(field.Value)
+	}
+	switch component.Name {
+	case componentSecond:
+		field.Value, field.Matches = c.second, s.second.Contains(c.second)
+	case componentMinute:
+		field.Value, field.Matches = c.minute, s.minute.Contains(c.minute)
+	case componentHour:
+		field.Value, field.Matches = c.hour, s.hour.Contains(c.hour)
+	case componentDayOfMonth:
+		field.Value, field.Matches = c.day, s.dayOfMonthMatches(c.year, c.month, c.day)
+		matches = func(parsed ParsedComponent) bool {
+			return parsed.Set.Contains(c.day) || matchesDate(parsed.Matchers, c.year, c.month, c.day)
+		}
+	case componentMonth:
+		field.Value, field.Matches = c.month, s.month.Contains(c.month)
+	case componentDayOfWeek:
+		weekday := int(weekdayOf(c.year, c.month, c.day))
+		field.Value, field.Matches = weekday+s.firstWeekday(), s.dayOfWeekMatches(c.year, c.month, c.day)
+		matches = func(parsed ParsedComponent) bool {
+			return parsed.Set.Contains(weekday) || matchesDate(parsed.Matchers, c.year, c.month, c.day)
+		}
+	case componentYear:
+		field.Value, field.Matches = c.year, s.year != nil && s.year.Contains(c.year)
+	default:
+		// a custom component doesn't take part in the schedule
+		return field
+	}
+	if field.Matches {
+		field.Item, field.Offset = s.matchingItem(component, matches)
+	}
+	return field
+}
+
+// firstWeekday returns the number of sunday in the day of week component of
+// the parser that created the schedule, which is 1 for Quartz, otherwise 0
+func (s *Schedule) firstWeekday() int {
+	for _, componentParser := range s.parser {
+		if componentParser.Name == componentDayOfWeek && componentParser.newParser != nil {
+			return componentParser.factory.Start()
+		}
+	}
+	return 0
+}
+
+// matchingItem will find the item of a component that matches, by parsing
+// each of its items on its own with the parser that created the schedule
+func (s *Schedule) matchingItem(component ParsedComponent, matches func(parsed ParsedComponent) bool) (string, int) {
+	items := strings.Split(component.Text, ",")
+	if len(items) == 1 {
+		return component.Text, 0
+	}
+	offset := 0
+	for _, item := range items {
+		if parsed, ok := s.parseItem(component.Name, item); ok && matches(parsed) {
+			return item, offset
+		}
+		offset += len(item) + len(",")
+	}
+	return "", 0
+}
+
+// parseItem will parse an item of a component with the component parser of
+// the same name
+func (s *Schedule) parseItem(name, item string) (ParsedComponent, bool) {
+	for _, componentParser := range s.parser {
+		if componentParser.Name != name {
+			continue
+		}
+		num, err := componentParser.Parser.Parse(item)
+		if err != nil {
+			return ParsedComponent{}, false
+		}
+		return newParsedComponent(name, item, num), true
+	}
+	return ParsedComponent{}, false
+}
+
+// String implements fmt.Stringer and returns the explanation with a line for
+// each of the fields, e.g.
+//
+//	time           2020-01-06 09:30:00 +0000 UTC
+//	runs           yes
+//	minute         30 matched by (*/15)
+//	hour           9 matched by (9-17)
+//	day of month   6 matched by (*)
+//	month          1 matched by (*)
+//	day of week    1 matched by (MON-FRI)
+//	days           day of month and day of week
+func (e Explanation) String() string {
+	lines := []string{
+		fmt.Sprintf("%-14s %s", "time", e.Time.Format("2006-01-02 15:04:05.999999999 -0700 MST")),
+		fmt.Sprintf("%-14s %s", "runs", yesNo(e.Matches)),
+	}
+	for _, field := range e.Fields {
+		lines = append(lines, field.String())
+	}
+	if len(e.Fields) > 0 {
+		days := "day of month and day of week"
+		if e.EitherDay {
+			days = "day of month or day of week"
+		}
+		lines = append(lines, fmt.Sprintf("%-14s %s", "days", days))
+	}
+	for _, note := range e.Notes {
+		lines = append(lines, fmt.Sprintf("%-14s %s", "note", note))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// String implements fmt.Stringer and returns the field on a line, e.g.
+//
+//	hour           9 matched by (9-17)
+func (f FieldExplanation) String() string {
+	switch {
+	case !f.Matches:
+		return fmt.Sprintf("%-14s %d not matched by (%s)", f.Name, f.Value, f.Component)
+	case f.Item == "":
+		return fmt.Sprintf("%-14s %d matched by (%s)", f.Name, f.Value, f.Component)
+	}
+	return fmt.Sprintf("%-14s %d matched by (%s)", f.Name, f.Value, f.Item)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
@@ -0,0 +1,314 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestSchedule_Matches(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+		location   string
+		at         string
+		expected   bool
+	}{
+		{name: "matches", expression: "*/15 9-17 * * MON-FRI", at: "2020-01-06T09:30:00Z", expected: true},
+		{name: "wrong minute", expression: "*/15 9-17 * * MON-FRI", at: "2020-01-06T09:31:00Z"},
+		{name: "weekend", expression: "*/15 9-17 * * MON-FRI", at: "2020-01-04T09:30:00Z"},
+		{name: "seconds past the minute", expression: "*/15 9-17 * * MON-FRI", at: "2020-01-06T09:30:01Z"},
+		{name: "nanoseconds past the second", expression: "*/15 9-17 * * MON-FRI", at: "2020-01-06T09:30:00.5Z"},
+		{name: "second", parser: cronparse.SecondsCronParser, expression: "30 0 9 * * *", at: "2020-01-06T09:00:30Z", expected: true},
+		{name: "either day", expression: "0 0 13 * FRI", at: "2020-01-03T00:00:00Z", expected: true},
+		{name: "in the time zone", expression: "CRON_TZ=Europe/Berlin 0 9 * * *", at: "2020-01-06T08:00:00Z", expected: true},
+		{name: "gap runs at the change", expression: "CRON_TZ=Europe/Berlin 30 2 * * *", at: "2021-03-28T03:00:00+02:00", expected: true},
+		{name: "overlap runs at the first", expression: "CRON_TZ=Europe/Berlin 30 2 * * *", at: "2021-10-31T02:30:00+02:00", expected: true},
+		{name: "overlap doesn't run at the second instance", expression: "CRON_TZ=Europe/Berlin 30 2 * * *", at: "2021-10-31T02:30:00+01:00"},
+		{name: "every", expression: "@every 1h", at: "2020-01-06T09:00:00Z", expected: true},
+		{name: "every between runs", expression: "@every 1h", at: "2020-01-06T09:30:00Z"},
+		{name: "reboot", expression: "@reboot", at: "0001-01-01T00:00:00Z"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := test.parser
+			if parser == nil {
+				parser = cronparse.CronParser
+			}
+			schedule, err := parser.Schedule(strings.Fields(test.expression))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := schedule.Matches(mustTime(t, test.at)); got != test.expected {
+				t.Fatalf("expected (%t), got (%t)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_Explain(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+		at         string
+		matches    bool
+		either     bool
+		fields     []cronparse.FieldExplanation
+	}{
+		{
+			name:       "items",
+			expression: "0,*/15 9-12,14-17 1,15 * MON-FRI",
+			at:         "2020-01-06T14:30:00Z",
+			matches:    true,
+			either:     true,
+			fields: []cronparse.FieldExplanation{
+				{Name: "minute", Value: 30, Matches: true, Component: "0,*/15", Item: "*/15", Offset: 2},
+				{Name: "hour", Value: 14, Matches: true, Component: "9-12,14-17", Item: "14-17", Offset: 5},
+				{Name: "day of month", Value: 6, Component: "1,15"},
+				{Name: "month", Value: 1, Matches: true, Component: "*", Item: "*"},
+				{Name: "day of week", Value: 1, Matches: true, Component: "MON-FRI", Item: "MON-FRI"},
+			},
+		},
+		{
+			name:       "macro",
+			expression: "@daily",
+			at:         "2020-01-06T01:00:00Z",
+			fields: []cronparse.FieldExplanation{
+				{Name: "minute", Value: 0, Matches: true, Component: "0", Item: "0"},
+				{Name: "hour", Value: 1, Component: "0"},
+				{Name: "day of month", Value: 6, Matches: true, Component: "*", Item: "*"},
+				{Name: "month", Value: 1, Matches: true, Component: "*", Item: "*"},
+				{Name: "day of week", Value: 1, Matches: true, Component: "*", Item: "*"},
+			},
+		},
+		{
+			name:       "quartz date matchers",
+			parser:     cronparse.QuartzParser,
+			expression: "0 0 0 1,L * ?",
+			at:         "2020-02-29T00:00:00Z",
+			matches:    true,
+			fields: []cronparse.FieldExplanation{
+				{Name: "second", Value: 0, Matches: true, Component: "0", Item: "0"},
+				{Name: "minute", Value: 0, Matches: true, Component: "0", Item: "0"},
+				{Name: "hour", Value: 0, Matches: true, Component: "0", Item: "0"},
+				{Name: "day of month", Value: 29, Matches: true, Component: "1,L", Item: "L", Offset: 2},
+				{Name: "month", Value: 2, Matches: true, Component: "*", Item: "*"},
+				{Name: "day of week", Value: 7, Matches: true, Component: "?", Item: "?"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser := test.parser
+			if parser == nil {
+				parser = cronparse.CronParser
+			}
+			schedule, err := parser.Schedule(strings.Fields(test.expression))
+			if err != nil {
+				t.Fatal(err)
+			}
+			explanation := schedule.Explain(mustTime(t, test.at))
+			if explanation.Matches != test.matches {
+				t.Fatalf("expected (%t), got (%t)", test.matches, explanation.Matches)
+			}
+			if explanation.EitherDay != test.either {
+				t.Fatalf("expected (%t), got (%t)", test.either, explanation.EitherDay)
+			}
+			if !reflect.DeepEqual(test.fields, explanation.Fields) {
+				t.Fatalf("expected (%+v), got (%+v)", test.fields, explanation.Fields)
+			}
+		})
+	}
+}
+
+func TestSchedule_ExplainQuartzDayOfWeek(t *testing.T) {
+	schedule, err := cronparse.QuartzParser.Schedule(strings.Fields("0 0 0 ? * 6L"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := schedule.Explain(mustTime(t, "2026-03-27T00:00:00Z")).Fields[5].String()
+	expected := "day of week    6 matched by (6L)"
+	if got != expected {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+}
+
+func TestSchedule_ExplainNotes(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		at         string
+		expected   []string
+	}{
+		{
+			name:       "no notes",
+			expression: "30 2 * * *",
+			at:         "2021-03-28T02:30:00Z",
+		},
+		{
+			name:       "seconds",
+			expression: "30 2 * * *",
+			at:         "2021-03-28T02:30:10Z",
+			expected:   []string{"the schedule has no second component, so it only runs at the start of a minute"},
+		},
+		{
+			name:       "gap",
+			expression: "CRON_TZ=Europe/Berlin 30 2 * * *",
+			at:         "2021-03-28T03:00:00+02:00",
+			expected: []string{
+				"the clocks went forward over a time that the schedule runs at, and the (vixie) DST policy runs at the end of the gap instead",
+			},
+		},
+		{
+			name:       "overlap",
+			expression: "CRON_TZ=Europe/Berlin 30 2 * * *",
+			at:         "2021-10-31T02:30:00+01:00",
+			expected:   []string{"the clocks go back over this time, and the (vixie) DST policy doesn't run at this instance of it"},
+		},
+		{
+			name:       "reboot",
+			expression: "@reboot",
+			at:         "2021-10-31T02:30:00Z",
+			expected:   []string{"the schedule runs when the system starts, not at a time"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := mustSchedule(t, test.expression).Explain(mustTime(t, test.at)).Notes
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_ExplainDate(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		dst        cronparse.DSTPolicy
+		hour       int
+		day        int
+		time       string
+		matches    bool
+		notes      []string
+	}{
+		{
+			name:       "gap shifted",
+			expression: "CRON_TZ=Europe/Berlin 30 2 * * *",
+			day:        29,
+			hour:       2,
+			time:       "2026-03-29T02:30:00+01:00",
+			matches:    true,
+			notes: []string{
+				"the clocks went forward over this time, so it runs at (03:00:00 CEST) instead (vixie: shifted to the end of the gap)",
+			},
+		},
+		{
+			name:       "gap skipped",
+			expression: "CRON_TZ=Europe/Berlin 30 2 * * *",
+			dst:        cronparse.DSTSkip,
+			day:        29,
+			hour:       2,
+			time:       "2026-03-29T02:30:00+01:00",
+			notes:      []string{"the clocks went forward over this time, so it doesn't run (skip: skipped)"},
+		},
+		{
+			name:       "gap repeated",
+			expression: "CRON_TZ=Europe/Berlin 30 2 * * *",
+			dst:        cronparse.DSTRepeat,
+			day:        29,
+			hour:       2,
+			time:       "2026-03-29T02:30:00+01:00",
+			notes:      []string{"the clocks went forward over this time, so it doesn't run (repeat: skipped)"},
+		},
+		{
+			name:       "gap wildcard",
+			expression: "CRON_TZ=Europe/Berlin */30 2 * * *",
+			day:        29,
+			hour:       2,
+			time:       "2026-03-29T02:30:00+01:00",
+			notes:      []string{"the clocks went forward over this time, so it doesn't run (vixie: skipped, as the minute or hour is a wildcard)"},
+		},
+		{
+			name:       "gap not matched",
+			expression: "CRON_TZ=Europe/Berlin 30 9 * * *",
+			day:        29,
+			hour:       2,
+			time:       "2026-03-29T02:30:00+01:00",
+			notes:      []string{"the clocks went forward over this time, so it doesn't exist"},
+		},
+		{
+			name:       "exists",
+			expression: "CRON_TZ=Europe/Berlin 30 2 * * *",
+			day:        30,
+			hour:       2,
+			time:       "2026-03-30T02:30:00+02:00",
+			matches:    true,
+		},
+		{
+			name:       "after the gap",
+			expression: "CRON_TZ=Europe/Berlin 30 2 * * *",
+			day:        29,
+			hour:       3,
+			time:       "2026-03-29T03:30:00+02:00",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule := mustSchedule(t, test.expression)
+			schedule.DST = test.dst
+			explanation := schedule.ExplainDate(2026, time.March, test.day, test.hour, 30, 0, 0, time.UTC)
+			if got := explanation.Time.Format(time.RFC3339); got != test.time {
+				t.Fatalf("expected (%s), got (%s)", test.time, got)
+			}
+			if explanation.Matches != test.matches {
+				t.Fatalf("expected (%t), got (%t)", test.matches, explanation.Matches)
+			}
+			if hour := explanation.Fields[1]; hour.Value != test.hour {
+				t.Fatalf("expected (%d), got (%d)", test.hour, hour.Value)
+			}
+			if !reflect.DeepEqual(test.notes, explanation.Notes) {
+				t.Fatalf("expected (%+v), got (%+v)", test.notes, explanation.Notes)
+			}
+		})
+	}
+}
+
+func TestExplanation_String(t *testing.T) {
+	got := mustSchedule(t, "CRON_TZ=Europe/Berlin 0,30 9 1 * MON").Explain(mustTime(t, "2020-01-06T08:30:00Z")).String()
+	expected := "time           2020-01-06 09:30:00 +0100 CET\n" +
+		"runs           yes\n" +
+		"minute         30 matched by (30)\n" +
+		"hour           9 matched by (9)\n" +
+		"day of month   6 not matched by (1)\n" +
+		"month          1 matched by (*)\n" +
+		"day of week    1 matched by (MON)\n" +
+		"days           day of month or day of week"
+	if got != expected {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+}
+
+func TestNewSchedule_Explain(t *testing.T) {
+	schedule, err := cronparse.NewSchedule([]cronparse.ParsedComponent{
+		{Name: "minute", Text: "0,30", Numbers: []int{0, 30}},
+		{Name: "hour", Text: "9", Numbers: []int{9}},
+		{Name: "day of month", Text: "*", Numbers: []int{1}, Wildcard: true},
+		{Name: "month", Text: "*", Numbers: []int{1}, Wildcard: true},
+		{Name: "day of week", Text: "*", Numbers: []int{3}, Wildcard: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := schedule.Explain(time.Date(2020, 1, 1, 9, 30, 0, 0, time.UTC)).Fields[0]
+	expected := cronparse.FieldExplanation{Name: "minute", Value: 30, Matches: true, Component: "0,30"}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
@@ -0,0 +1,95 @@
+package cronparse
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Expression is the result of parsing a whole line, such as a line of a
+// crontab, into its schedule and the command that it runs
+type Expression struct {
+	// Components are the components of the schedule as they were written,
+	// including a time zone or macro, they can be given to Diagnostic with an
+	// error from ParseExpression
+	Components []string
+	// Schedule is the schedule of the expression
+	Schedule *Schedule
+	// Command is the text after the schedule, with its spacing as it was
+	// written, it is empty if there isn't a command
+	Command string
+}
+
+// ParseExpression will parse a whole line into its schedule and the command
+// after it, the components can be separated by any amount of whitespace. The
+// line can start with a time zone, and a macro can be used in place of the
+// components, e.g.
+//
+//	CRON_TZ=Europe/London  0 9 * * MON-FRI	/usr/bin/find . -name  "*.go"
+//
+// The Components of the Expression are set even if there is an error, so that
+// it can be given to Diagnostic.
+func (p Parser) ParseExpression(line string) (Expression, error) {
+	line = strings.TrimRight(line, "\r\n")
+	fields := splitFields(line)
+	count := p.componentCount(fields)
+	if count > len(fields) {
+		count = len(fields)
+	}
+	expr := Expression{Components: make([]string, 0, count)}
+	for _, field := range fields[:count] {
+		expr.Components = append(expr.Components, field.text)
+	}
+	if count < len(fields) {
+		expr.Command = line[fields[count].offset:]
+	}
+	schedule, err := p.Schedule(expr.Components)
+	if err != nil {
+		return expr, err
+	}
+	expr.Schedule = schedule
+	return expr, nil
+}
+
+// componentCount returns how many of the fields at the start of a line are
+// the components of the schedule, the rest are the command
+func (p Parser) componentCount(fields []lineField) int {
+	count := 0
+	if len(fields) > 0 && hasTimeZone([]string{fields[0].text}) {
+		count++
+	}
+	if count >= len(fields) || !strings.HasPrefix(fields[count].text, macroPrefix) {
+		return count + len(p)
+	}
+	if fields[count].text == everyMacro {
+		// @every is followed by its interval
+		return count + 2
+	}
+	return count + 1
+}
+
+// lineField is a field of a line that is separated by whitespace, and the
+// byte offset that it starts at
+type lineField struct {
+	text   string
+	offset int
+}
+
+// splitFields will split a line around its whitespace, in the same way as
+// strings.Fields, keeping where each of the fields starts
+func splitFields(line string) []lineField {
+	var fields []lineField
+	start := -1
+	for i, r := range line {
+		switch {
+		case unicode.IsSpace(r) && start >= 0:
+			fields = append(fields, lineField{text: line[start:i], offset: start})
+			start = -1
+		case !unicode.IsSpace(r) && start < 0:
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, lineField{text: line[start:], offset: start})
+	}
+	return fields
+}
@@ -0,0 +1,140 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestParser_ParseExpression(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		line       string
+		components []string
+		command    string
+		kind       cronparse.ScheduleKind
+	}{
+		{
+			name:       "tabs and repeated spaces",
+			parser:     cronparse.CronParser,
+			line:       "*/15\t0  1,15 *   1-5 /usr/bin/find",
+			components: []string{"*/15", "0", "1,15", "*", "1-5"},
+			command:    "/usr/bin/find",
+		},
+		{
+			name:       "command spacing is kept",
+			parser:     cronparse.CronParser,
+			line:       "0 9 * * MON-FRI\t/usr/bin/find .  -name \"*.go\"  \n",
+			components: []string{"0", "9", "*", "*", "MON-FRI"},
+			command:    "/usr/bin/find .  -name \"*.go\"  ",
+		},
+		{
+			name:       "leading whitespace and no command",
+			parser:     cronparse.CronParser,
+			line:       "  0 9 * * *  ",
+			components: []string{"0", "9", "*", "*", "*"},
+		},
+		{
+			name:       "time zone",
+			parser:     cronparse.CronParser,
+			line:       "CRON_TZ=Europe/London 0 9 * * * backup --all",
+			components: []string{"CRON_TZ=Europe/London", "0", "9", "*", "*", "*"},
+			command:    "backup --all",
+		},
+		{
+			name:       "macro",
+			parser:     cronparse.CronParser,
+			line:       "@daily   backup",
+			components: []string{"@daily"},
+			command:    "backup",
+		},
+		{
+			name:       "every",
+			parser:     cronparse.CronParser,
+			line:       "TZ=UTC @every 1h30m backup",
+			components: []string{"TZ=UTC", "@every", "1h30m"},
+			command:    "backup",
+			kind:       cronparse.ScheduleKindEvery,
+		},
+		{
+			name:       "reboot",
+			parser:     cronparse.CronParser,
+			line:       "@reboot /usr/bin/start",
+			components: []string{"@reboot"},
+			command:    "/usr/bin/start",
+			kind:       cronparse.ScheduleKindReboot,
+		},
+		{
+			name:       "seconds",
+			parser:     cronparse.SecondsCronParser,
+			line:       "0 */20 0 9 * * MON /usr/bin/find",
+			components: []string{"0", "*/20", "0", "9", "*", "*"},
+			command:    "MON /usr/bin/find",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expr, err := test.parser.ParseExpression(test.line)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(test.components, expr.Components) {
+				t.Fatalf("expected (%+v), got (%+v)", test.components, expr.Components)
+			}
+			if test.command != expr.Command {
+				t.Fatalf("expected (%q), got (%q)", test.command, expr.Command)
+			}
+			if test.kind != expr.Schedule.Kind {
+				t.Fatalf("expected (%s), got (%s)", test.kind, expr.Schedule.Kind)
+			}
+		})
+	}
+}
+
+func TestParser_ParseExpressionFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		line       string
+		components []string
+		diagnostic string
+	}{
+		{
+			name:       "invalid component",
+			line:       "0  24 * * *   backup",
+			components: []string{"0", "24", "*", "*", "*"},
+			diagnostic: "(hour): number (24) must be in range (0-23)\n" +
+				"    0 24 * * *\n" +
+				"      ^^\n" +
+				"    hour allows a number (0-23) or (*)\n" +
+				"    help: did you mean 0 (midnight)?",
+		},
+		{
+			name:       "too few components",
+			line:       "0 9 *",
+			components: []string{"0", "9", "*"},
+			diagnostic: "expected (5) components, got (3) components",
+		},
+		{
+			name:       "empty",
+			line:       " \t",
+			components: []string{},
+			diagnostic: "expected (5) components, got (0) components",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expr, err := cronparse.CronParser.ParseExpression(test.line)
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if !reflect.DeepEqual(test.components, expr.Components) {
+				t.Fatalf("expected (%+v), got (%+v)", test.components, expr.Components)
+			}
+			if got := cronparse.Diagnostic(expr.Components, err); got != test.diagnostic {
+				t.Fatalf("expected (%s), got (%s)", test.diagnostic, got)
+			}
+		})
+	}
+}
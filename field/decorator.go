@@ -0,0 +1,169 @@
+package field
+
+import (
+	"strconv"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+	"github.com/alistairjudson/cronparse/internal/numberer"
+	"github.com/alistairjudson/cronparse/internal/parse"
+)
+
+// Decorator is a type that adds to the syntax of the items of a field, or
+// changes the values that they stand for, it is added to a Parser with With
+type Decorator struct {
+	decorate func(factory numberer.Factory, base parse.NumbererProvider) parse.NumbererProvider
+	// steps is set for decorators that give a meaning to (/), and anySyntax
+	// for decorators that may give a meaning to any of the syntax
+	steps, anySyntax bool
+}
+
+// Step returns a Decorator that allows steps (/n) after an item, which take
+// every nth value of it, the step must be at least 1 and no more than the
+// number of values in the field. A step after a single value runs from that
+// value to the end of the field, so (5/15) is the same as (5-59/15) for
+// minutes.
+func Step() Decorator {
+	return Decorator{
+		decorate: func(factory numberer.Factory, base parse.NumbererProvider) parse.NumbererProvider {
+			return parse.NewStepNumbererFactory(base, len(factory.Any()))
+		},
+		steps: true,
+	}
+}
+
+// Item is an item of a field, the text between its commas
+type Item struct {
+	Text string
+	// Offset is the byte offset of the text within the field
+	Offset int
+}
+
+// ItemParser is a function that can parse an item into the values that it
+// stands for, in the order that it runs through them
+type ItemParser func(item Item) ([]int, error)
+
+// Func returns a Decorator that parses each item with fn, which is given the
+// ItemParser of the decorators before it as next. fn can handle the item
+// itself, or pass it, or different text, on to next e.g. to replace a word
+// with a range. Items are tokenised before they are decorated, so fn can give
+// a meaning to the syntax of the standard fields, but not add new characters.
+// The values that fn returns must be within the bounds of the field, or the
+// item is reported as a RangeError.
+func Func(fn func(item Item, next ItemParser) ([]int, error)) Decorator {
+	return Decorator{
+		decorate: func(factory numberer.Factory, base parse.NumbererProvider) parse.NumbererProvider {
+			return funcProvider{fn: fn, base: base, values: factory.Any()}
+		},
+		anySyntax: true,
+	}
+}
+
+// funcProvider is a parse.NumbererProvider that adapts the function of a Func
+// decorator, values are all of the values of the field, which the values that
+// the function returns are checked against
+type funcProvider struct {
+	fn     func(item Item, next ItemParser) ([]int, error)
+	base   parse.NumbererProvider
+	values numberer.Any
+}
+
+// Numberer implements parse.NumbererProvider and will parse the part with the
+// function
+func (f funcProvider) Numberer(part parse.Part) (parse.Numberer, error) {
+	item := Item{Text: part.String(), Offset: part[0].Pos}
+	values, err := f.fn(item, f.next(part))
+	if err != nil {
+		return nil, err
+	}
+	min, max := f.values[0], f.values[len(f.values)-1]
+	for _, value := range values {
+		if value < min || value > max {
+			return nil, cronerr.Locate(&RangeError{
+				What:  "value of the item",
+				Value: strconv.Itoa(value),
+				Min:   min,
+				Max:   max,
+			}, item.Offset, len(item.Text))
+		}
+	}
+	return numberer.Any(values), nil
+}
+
+// next returns the ItemParser that parses an item with the base, the item is
+// tokenised again if it isn't the part that was given to the function
+func (f funcProvider) next(original parse.Part) ItemParser {
+	return func(item Item) ([]int, error) {
+		part := original
+		if item.Text != original.String() || item.Offset != original[0].Pos {
+			var err error
+			if part, err = tokenise(item); err != nil {
+				return nil, err
+			}
+		}
+		num, err := f.base.Numberer(part)
+		if err != nil {
+			return nil, err
+		}
+		return num.Numbers(), nil
+	}
+}
+
+// tokenise will split an item into its tokens, positioned within the field
+func tokenise(item Item) (parse.Part, error) {
+	parts, err := parse.NewPartitioner(parse.AnySyntax).Parts(item.Text)
+	if err != nil {
+		return nil, shift(err, item.Offset)
+	}
+	if len(parts) != 1 {
+		return nil, &SyntaxError{
+			Position: Position{Offset: item.Offset, Length: len(item.Text)},
+			Value:    item.Text,
+			Context:  "as an item",
+			Expected: "a single item without commas",
+		}
+	}
+	part := make(parse.Part, 0, len(parts[0]))
+	for _, token := range parts[0] {
+		token.Pos += item.Offset
+		part = append(part, token)
+	}
+	return part, nil
+}
+
+// shift will move the position of err along the field by offset
+func shift(err error, offset int) error {
+	if errs, ok := err.(Errors); ok {
+		for _, err := range errs {
+			shift(err, offset)
+		}
+		return errs
+	}
+	located, ok := err.(cronerr.Located)
+	if !ok {
+		return err
+	}
+	position := located.Where()
+	return cronerr.Locate(err, position.Offset+offset, position.Length)
+}
+
+// withoutSteps is a parse.NumbererProvider for fields that don't have the
+// Step decorator, which would otherwise ignore a step after (*)
+type withoutSteps struct {
+	base parse.NumbererProvider
+}
+
+// Numberer implements parse.NumbererProvider and will return an error for
+// parts that contain a step, otherwise it will return the base
+func (w withoutSteps) Numberer(part parse.Part) (parse.Numberer, error) {
+	for _, token := range part {
+		if token.Type == parse.TokenTypeSlash {
+			return nil, &SyntaxError{
+				Position: Position{Offset: token.Pos, Length: len(token.Value)},
+				Value:    token.Value,
+				Context:  "in this field",
+				Expected: "a number, a range or (*)",
+			}
+		}
+	}
+	return w.base.Numberer(part)
+}
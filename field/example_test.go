@@ -0,0 +1,85 @@
+package field_test
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alistairjudson/cronparse"
+	"github.com/alistairjudson/cronparse/field"
+)
+
+func ExampleNew() {
+	minute, err := field.New("minute", 0, 59)
+	if err != nil {
+		panic(err)
+	}
+	set, err := minute.With(field.Step()).Parse("*/15,7")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(set.Values(), set.Contains(30), set.Contains(31))
+	// Output: [0 7 15 30 45] true false
+}
+
+func ExampleNewNamed() {
+	season, err := field.NewNamed("season", 1, 4, []string{"SPRING", "SUMMER", "AUTUMN", "WINTER"})
+	if err != nil {
+		panic(err)
+	}
+	set, err := season.WithWrapAround().Parse("WINTER-SPRING")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(set.Values())
+	// Output: [1 4]
+}
+
+func ExampleFunc() {
+	hour, err := field.New("hour", 0, 23)
+	if err != nil {
+		panic(err)
+	}
+	businessHours := field.Func(func(item field.Item, next field.ItemParser) ([]int, error) {
+		if strings.EqualFold(item.Text, "BH") {
+			return next(field.Item{Text: "9-17", Offset: item.Offset})
+		}
+		return next(item)
+	})
+	set, err := hour.With(field.Step(), businessHours).Parse("BH,0")
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(set.Values())
+	// Output: [0 9 10 11 12 13 14 15 16 17]
+}
+
+func ExampleParser_Parse_errors() {
+	minute, err := field.New("minute", 0, 59)
+	if err != nil {
+		panic(err)
+	}
+	_, err = minute.Parse("1,60")
+	fmt.Println(err)
+	// Output: (minute): number (60) must be in range (0-59)
+}
+
+func Example_component() {
+	hour, err := field.New("hour", 0, 23)
+	if err != nil {
+		panic(err)
+	}
+	parser := cronparse.Parser{
+		cronparse.FieldComponent(hour.With(field.Step())),
+		cronparse.CronParser[2],
+	}
+	components, err := parser.Parse([]string{"*/6", "1,15"})
+	if err != nil {
+		panic(err)
+	}
+	for _, component := range components {
+		fmt.Println(component)
+	}
+	// Output:
+	// hour           0 6 12 18
+	// day of month   1 15
+}
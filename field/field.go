@@ -0,0 +1,217 @@
+// Package field lets you build parsers for the fields of a cron expression,
+// with their own names, bounds and syntax, from the same building blocks that
+// cronparse uses for the standard fields. A field parser can be used on its
+// own, or as a component of a cronparse.Parser with cronparse.FieldComponent.
+//
+// The exported API of this package follows the compatibility promise of the
+// module: within a major version, the exported names won't be removed or
+// changed in a way that breaks code that uses them, and a field that parses
+// an input will keep giving the same values for it. The messages of the
+// errors may be improved, so match on their types rather than their text.
+package field
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+	"github.com/alistairjudson/cronparse/internal/numberer"
+	"github.com/alistairjudson/cronparse/internal/parse"
+)
+
+// Set is a type that holds the values of a field as a bitmask, so that you can
+// tell whether it contains a value, or find the next value after one, in
+// constant time
+type Set = parse.Set
+
+// Position is where a problem is within a field, Offset and Length are in
+// bytes within it
+type Position = cronerr.Position
+
+// SyntaxError is returned for text in a field that isn't valid syntax
+type SyntaxError = cronerr.SyntaxError
+
+// RangeError is returned for a value that is outside of the bounds of a field
+type RangeError = cronerr.RangeError
+
+// OrderError is returned for a range whose start is after its end, when the
+// field doesn't allow ranges to wrap around, see WithWrapAround
+type OrderError = cronerr.OrderError
+
+// StepError is returned for a step that is less than one, or more than the
+// number of values in a field
+type StepError = cronerr.StepError
+
+// Errors is returned by parsers created with WithAllErrors, it lists all of
+// the errors in a field in the order that they appear, range over it to
+// inspect each of them with errors.As
+type Errors = cronerr.Errors
+
+// New will create a Parser for a field called name that allows the numbers
+// from start to end, the items of the field can be a number, a range of numbers
+// (1-5), or (*) for all of them. Add decorators with With to allow more.
+func New(name string, start, end int) (Parser, error) {
+	factory, err := numberer.NewFactory(name, start, end)
+	if err != nil {
+		return Parser{}, err
+	}
+	return Parser{name: name, factory: factory}.build(), nil
+}
+
+// NewNamed will create a Parser in the same way as New, that also allows
+// names in place of the numbers, the names are matched case insensitively and
+// are given in order from start e.g. SUN-SAT for the numbers 0-6. The names
+// must be made of letters, and can't be L, W or LW, which are the Quartz
+// special characters.
+func NewNamed(name string, start, end int, names []string) (Parser, error) {
+	for _, valueName := range names {
+		if !isName(valueName) {
+			return Parser{}, fmt.Errorf("(%s) name (%s) must be made of letters, and not be (L), (W) or (LW)", name, valueName)
+		}
+	}
+	factory, err := numberer.NewNamedFactory(name, start, end, names)
+	if err != nil {
+		return Parser{}, err
+	}
+	return Parser{name: name, factory: factory}.build(), nil
+}
+
+// isName tells you whether the tokeniser will read text as a name
+func isName(text string) bool {
+	switch strings.ToUpper(text) {
+	case "", "L", "W", "LW":
+		return false
+	}
+	for _, r := range text {
+		if !unicode.IsLetter(r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Parser is a type that can parse a field of a cron expression into the Set of
+// values that it stands for, the zero value isn't usable, use New or NewNamed
+type Parser struct {
+	name       string
+	factory    numberer.Factory
+	decorators []Decorator
+	allErrors  bool
+	parser     parse.Parser
+}
+
+// Name returns the name of the field, which is used in its errors
+func (p Parser) Name() string {
+	return p.name
+}
+
+// Allowed describes the values that the field allows, e.g. a number (0-59)
+// or (*)
+func (p Parser) Allowed() string {
+	return parse.SyntaxOf(p.factory).Allowed(p.factory.Descriptions())
+}
+
+// With will return a copy of the parser with the decorators added, each
+// decorator wraps the ones before it, so the last one sees each item first
+func (p Parser) With(decorators ...Decorator) Parser {
+	p.decorators = append(append([]Decorator(nil), p.decorators...), decorators...)
+	return p.build()
+}
+
+// WithWrapAround will return a copy of the parser that accepts ranges where
+// the start is after the end, which wrap around from the end of the field to
+// its start e.g. (22-2) for hours is 22 23 0 1 2
+func (p Parser) WithWrapAround() Parser {
+	p.factory = p.factory.WithWrapAround()
+	return p.build()
+}
+
+// WithWrappingEnd will return a copy of the parser that accepts values up to
+// end, past the end of the field, which wrap around to its start e.g. 7 for
+// sunday in a day of week field (0-6)
+func (p Parser) WithWrappingEnd(end int) Parser {
+	p.factory = p.factory.WithWrappingEnd(end)
+	return p.build()
+}
+
+// WithAllErrors will return a copy of the parser that carries on after an
+// error, from the next comma, and returns every problem in the field as Errors
+func (p Parser) WithAllErrors() Parser {
+	p.allErrors = true
+	return p.build()
+}
+
+// Parse will parse the field into the Set of values that it stands for, the
+// errors have the Position of the problem within the field
+func (p Parser) Parse(field string) (Set, error) {
+	if p.parser == nil {
+		return Set{}, errors.New("field parser must be created with New or NewNamed")
+	}
+	num, err := p.parser.Parse(field)
+	if err != nil {
+		return Set{}, p.locate(err)
+	}
+	if withSet, ok := num.(interface{ Set() Set }); ok {
+		return withSet.Set(), nil
+	}
+	return parse.NewSet(num.Numbers()...), nil
+}
+
+// build will rebuild the parser from its factory and decorators
+func (p Parser) build() Parser {
+	var provider parse.NumbererProvider = parse.NewBaseNumbererFactory(p.factory)
+	if !p.steps() {
+		provider = withoutSteps{base: provider}
+	}
+	for _, decorator := range p.decorators {
+		provider = decorator.decorate(p.factory, provider)
+	}
+	parser := parse.Parser(parse.NewAdapter(provider, p.syntax()))
+	if p.allErrors {
+		parser = parse.WithAllErrors(parser)
+	}
+	p.parser = parser
+	return p
+}
+
+// syntax returns the syntax of the field, which is all of the syntax if one of
+// the decorators may give it a meaning
+func (p Parser) syntax() parse.Syntax {
+	for _, decorator := range p.decorators {
+		if decorator.anySyntax {
+			return parse.AnySyntax
+		}
+	}
+	syntax := parse.SyntaxOf(p.factory)
+	syntax.Steps = p.steps()
+	return syntax
+}
+
+// steps tells you whether one of the decorators of the parser allows steps
+func (p Parser) steps() bool {
+	for _, decorator := range p.decorators {
+		if decorator.steps {
+			return true
+		}
+	}
+	return false
+}
+
+// locate will set the field on the errors from parsing it, errors that don't
+// have a position are prefixed with the name of the field instead
+func (p Parser) locate(err error) error {
+	var errs Errors
+	if !errors.As(err, &errs) {
+		if cronerr.SetComponent(err, p.name, 0, p.Allowed()) {
+			return err
+		}
+		return fmt.Errorf("(%s): %w", p.name, err)
+	}
+	located := make(Errors, 0, len(errs))
+	for _, err := range errs {
+		located = append(located, p.locate(err))
+	}
+	return located
+}
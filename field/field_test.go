@@ -0,0 +1,279 @@
+package field_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/alistairjudson/cronparse/field"
+)
+
+func mustField(parser field.Parser, err error) field.Parser {
+	if err != nil {
+		panic(err)
+	}
+	return parser
+}
+
+func TestParser_Parse(t *testing.T) {
+	minute := mustField(field.New("minute", 0, 59))
+	day := mustField(field.NewNamed("day", 0, 6, []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}))
+	tests := []struct {
+		name     string
+		parser   field.Parser
+		input    string
+		expected []int
+	}{
+		{
+			name:     "list",
+			parser:   minute,
+			input:    "5,1-3",
+			expected: []int{1, 2, 3, 5},
+		},
+		{
+			name:     "any",
+			parser:   day,
+			input:    "*",
+			expected: []int{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:     "names",
+			parser:   day,
+			input:    "MON-WED,sat",
+			expected: []int{1, 2, 3, 6},
+		},
+		{
+			name:     "step",
+			parser:   minute.With(field.Step()),
+			input:    "*/15,5/20",
+			expected: []int{0, 5, 15, 25, 30, 45},
+		},
+		{
+			name:     "wrap around",
+			parser:   day.WithWrapAround().With(field.Step()),
+			input:    "FRI-MON/2",
+			expected: []int{0, 5},
+		},
+		{
+			name:     "wrapping end",
+			parser:   day.WithWrappingEnd(7),
+			input:    "7",
+			expected: []int{0},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set, err := test.parser.Parse(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := set.Values(); !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParser_ParseFails(t *testing.T) {
+	minute := mustField(field.New("minute", 0, 59))
+	tests := []struct {
+		name     string
+		parser   field.Parser
+		input    string
+		expected field.Position
+	}{
+		{
+			name:     "out of range",
+			parser:   minute,
+			input:    "1,60",
+			expected: field.Position{Field: "minute", Allowed: "a number (0-59) or (*)", Offset: 2, Length: 2},
+		},
+		{
+			name:     "step without the step decorator",
+			parser:   minute,
+			input:    "*/5",
+			expected: field.Position{Field: "minute", Allowed: "a number (0-59) or (*)", Offset: 1, Length: 1},
+		},
+		{
+			name:     "step out of range",
+			parser:   minute.With(field.Step()),
+			input:    "*/0",
+			expected: field.Position{Field: "minute", Allowed: "a number (0-59) or (*)", Offset: 2, Length: 1},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.parser.Parse(test.input)
+			var syntaxErr *field.SyntaxError
+			var rangeErr *field.RangeError
+			var stepErr *field.StepError
+			var got field.Position
+			switch {
+			case errors.As(err, &syntaxErr):
+				got = syntaxErr.Position
+			case errors.As(err, &rangeErr):
+				got = rangeErr.Position
+			case errors.As(err, &stepErr):
+				got = stepErr.Position
+			default:
+				t.Fatalf("expected a located error, got (%v)", err)
+			}
+			if got != test.expected {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParser_WithAllErrors(t *testing.T) {
+	minute := mustField(field.New("minute", 0, 59)).WithAllErrors()
+	_, err := minute.Parse("60,1,61")
+	var errs field.Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected errors, got (%v)", err)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected (2) errors, got (%d)", len(errs))
+	}
+}
+
+func TestParser_ParseExpected(t *testing.T) {
+	tests := []struct {
+		name     string
+		parser   field.Parser
+		input    string
+		expected string
+	}{
+		{
+			name:     "without steps",
+			parser:   mustField(field.New("minute", 0, 59)),
+			input:    "5x",
+			expected: "(minute): (x) is unexpected after a number, expected (,) or (-)",
+		},
+		{
+			name:     "with steps",
+			parser:   mustField(field.New("minute", 0, 59)).With(field.Step()),
+			input:    "5x",
+			expected: "(minute): (x) is unexpected after a number, expected (,), (-) or (/)",
+		},
+		{
+			name:     "named",
+			parser:   mustField(field.NewNamed("season", 1, 4, []string{"SPRING", "SUMMER", "AUTUMN", "WINTER"})),
+			input:    "!",
+			expected: "(season): (!) is unexpected at the start of the field, expected (*), a number or a name",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.parser.Parse(test.input)
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if got := err.Error(); got != test.expected {
+				t.Fatalf("expected (%s), got (%s)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestNew_Fails(t *testing.T) {
+	if _, err := field.New("backwards", 10, 1); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if _, err := field.NewNamed("short", 1, 3, []string{"ONE"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if _, err := field.NewNamed("quarter", 1, 2, []string{"Q1", "Q2"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if _, err := field.NewNamed("special", 1, 2, []string{"L", "M"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestParser_ParseZeroValue(t *testing.T) {
+	if _, err := (field.Parser{}).Parse("*"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestFunc(t *testing.T) {
+	hour := mustField(field.New("hour", 0, 23)).With(
+		field.Step(),
+		field.Func(func(item field.Item, next field.ItemParser) ([]int, error) {
+			// business hours
+			if strings.EqualFold(item.Text, "BH") {
+				return next(field.Item{Text: "9-17", Offset: item.Offset})
+			}
+			return next(item)
+		}),
+	)
+	tests := []struct {
+		name     string
+		input    string
+		expected []int
+	}{
+		{
+			name:     "replaced",
+			input:    "bh",
+			expected: []int{9, 10, 11, 12, 13, 14, 15, 16, 17},
+		},
+		{
+			name:     "passed on",
+			input:    "0,*/12",
+			expected: []int{0, 12},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set, err := hour.Parse(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := set.Values(); !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFunc_OutOfRange(t *testing.T) {
+	hour := mustField(field.New("hour", 0, 23)).With(
+		field.Func(func(item field.Item, next field.ItemParser) ([]int, error) {
+			if item.Text == "bad" {
+				return []int{99, -3}, nil
+			}
+			return next(item)
+		}),
+	)
+	_, err := hour.Parse("1,bad")
+	var rangeErr *field.RangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatalf("expected a range error, got (%v)", err)
+	}
+	expected := field.Position{Field: "hour", Allowed: "a number (0-23) or (*)", Offset: 2, Length: 3}
+	if rangeErr.Position != expected {
+		t.Fatalf("expected (%+v), got (%+v)", expected, rangeErr.Position)
+	}
+	if rangeErr.Value != "99" || rangeErr.Min != 0 || rangeErr.Max != 23 {
+		t.Fatalf("expected (99 0-23), got (%s %d-%d)", rangeErr.Value, rangeErr.Min, rangeErr.Max)
+	}
+}
+
+func TestFunc_PositionsReplacedItems(t *testing.T) {
+	hour := mustField(field.New("hour", 0, 23)).With(
+		field.Func(func(item field.Item, next field.ItemParser) ([]int, error) {
+			return next(field.Item{Text: "1-" + item.Text, Offset: item.Offset - 2})
+		}),
+	)
+	_, err := hour.Parse("1,30")
+	var rangeErr *field.RangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatalf("expected a range error, got (%v)", err)
+	}
+	expected := field.Position{Field: "hour", Allowed: "a number (0-23) or (*)", Offset: 2, Length: 2}
+	if rangeErr.Position != expected {
+		t.Fatalf("expected (%+v), got (%+v)", expected, rangeErr.Position)
+	}
+}
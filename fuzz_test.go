@@ -0,0 +1,66 @@
+package cronparse_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func FuzzParser_Parse(f *testing.F) {
+	for _, seed := range []string{
+		"*/15 0 1,15 * 1-5",
+		"*/0 * * * *",
+		"5/15 22-2 L-3 NOV-FEB FRI-MON",
+		"0 0 12 LW * ?",
+		"0 0 12 ? * THU#3",
+		"0 0 12 ? * 6L 2020-2030",
+		"CRON_TZ=Europe/London 0 9 * * 1-7",
+		"@every 1h30m",
+		"@daily",
+		"1-5-6/2,,L-W#",
+		"0 9 * * *\t/usr/bin/find  -name x",
+	} {
+		f.Add(seed)
+	}
+	parsers := []cronparse.Parser{
+		cronparse.CronParser,
+		cronparse.SecondsCronParser,
+		cronparse.SecondsYearCronParser,
+		cronparse.QuartzYearParser,
+		cronparse.CronParser.WithWrapAround(),
+		cronparse.QuartzParser.WithWrapAround(),
+		cronparse.QuartzYearParser.WithAllErrors(),
+	}
+	for _, preset := range []cronparse.Option{cronparse.Vixie, cronparse.AWS, cronparse.Robfig} {
+		parser, err := cronparse.New(preset, cronparse.WithNames(false))
+		if err != nil {
+			f.Fatal(err)
+		}
+		parsers = append(parsers, parser)
+	}
+	from := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	f.Fuzz(func(t *testing.T, expression string) {
+		components := strings.Fields(expression)
+		for _, parser := range append(parsers, cronparse.DetectParser(components)) {
+			if expr, err := parser.ParseExpression(expression); err != nil {
+				cronparse.Diagnostic(expr.Components, err)
+			}
+			if _, err := parser.Parse(components); err != nil {
+				cronparse.Diagnostic(components, err)
+				for _, suggestion := range cronparse.Suggestions(err) {
+					cronparse.Fix(components, err, suggestion)
+				}
+				continue
+			}
+			schedule, err := parser.Schedule(components)
+			if err != nil {
+				t.Fatalf("expected a schedule for (%s), got (%s)", expression, err)
+			}
+			schedule.Next(from)
+			schedule.Prev(from)
+			schedule.Explain(from)
+		}
+	})
+}
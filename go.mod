@@ -1,8 +1,10 @@
 module github.com/alistairjudson/cronparse
 
-go 1.14
+go 1.18
+
+require github.com/spf13/cobra v0.0.7
 
 require (
-	github.com/spf13/cobra v0.0.7
+	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/spf13/pflag v1.0.5 // indirect
 )
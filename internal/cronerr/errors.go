@@ -0,0 +1,253 @@
+// Package cronerr contains the errors for problems within the fields of a
+// cron expression, they record where in the field the problem is so that it
+// can be pointed out
+package cronerr
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Position is where a problem is within a field of a cron expression
+type Position struct {
+	// Field is the name of the component that the field is for
+	Field string
+	// Index is the index of the field within the expression, counting a
+	// time zone if the expression starts with one
+	Index int
+	// Allowed describes the values that the component allows, e.g. a number
+	// (0-59) or (*), it is empty if they aren't known
+	Allowed string
+	// Offset is the byte offset of the problem within the field
+	Offset int
+	// Length is the length in bytes of the problem, it is zero when
+	// something is missing from the end of the field
+	Length int
+}
+
+// Locate will set the offset and length of the problem within the field
+func (p *Position) Locate(offset, length int) {
+	p.Offset, p.Length = offset, length
+}
+
+// SetComponent will set the name of the component that the field is for,
+// the index of the field within the expression, and what the component allows
+func (p *Position) SetComponent(field string, index int, allowed string) {
+	p.Field, p.Index, p.Allowed = field, index, allowed
+}
+
+// Where returns the position, so that it can be read through Located
+func (p *Position) Where() Position {
+	return *p
+}
+
+func (p *Position) prefix() string {
+	if p.Field == "" {
+		return ""
+	}
+	return fmt.Sprintf("(%s): ", p.Field)
+}
+
+// Located is an error that has a position within a field
+type Located interface {
+	error
+	Locate(offset, length int)
+	SetComponent(field string, index int, allowed string)
+	Where() Position
+}
+
+// Locate will set the offset and length of err within the field, if it is a
+// Located error, and return it. Its suggestions are moved along with it.
+func Locate(err error, offset, length int) error {
+	var located Located
+	if !errors.As(err, &located) {
+		return err
+	}
+	var suggester Suggester
+	if errors.As(err, &suggester) {
+		suggester.shift(offset - located.Where().Offset)
+	}
+	located.Locate(offset, length)
+	return err
+}
+
+// Suggestion is a possible fix for a problem in a field, replacing the text
+// at Offset, for Length bytes, with Replacement
+type Suggestion struct {
+	// Offset and Length are where the text to replace is within the field,
+	// in the same way as the Position of the problem
+	Offset, Length int
+	// Replacement is the text to replace it with, it is empty if the text
+	// should be removed
+	Replacement string
+	// Message describes the fix, e.g. did you mean 0 (midnight)?
+	Message string
+}
+
+// Suggestions are the possible fixes for a problem
+type Suggestions []Suggestion
+
+// Suggest will add suggestions to the possible fixes
+func (s *Suggestions) Suggest(suggestions ...Suggestion) {
+	*s = append(*s, suggestions...)
+}
+
+// Suggested returns the possible fixes
+func (s *Suggestions) Suggested() []Suggestion {
+	return *s
+}
+
+// shift will move the suggestions along the field, for when the problem that
+// they are for is located
+func (s *Suggestions) shift(delta int) {
+	for i := range *s {
+		(*s)[i].Offset += delta
+	}
+}
+
+// Suggester is an error that can have suggested fixes
+type Suggester interface {
+	error
+	Suggest(suggestions ...Suggestion)
+	Suggested() []Suggestion
+	shift(delta int)
+}
+
+// Suggest will add suggestions to err if it is a Suggester, and return it
+func Suggest(err error, suggestions ...Suggestion) error {
+	var suggester Suggester
+	if errors.As(err, &suggester) {
+		suggester.Suggest(suggestions...)
+	}
+	return err
+}
+
+// Suggested returns the suggestions for err, if it has any
+func Suggested(err error) []Suggestion {
+	var suggester Suggester
+	if !errors.As(err, &suggester) {
+		return nil
+	}
+	return suggester.Suggested()
+}
+
+// SetComponent will set the component of err if it is a Located error, it
+// tells you whether it was
+func SetComponent(err error, field string, index int, allowed string) bool {
+	var located Located
+	if !errors.As(err, &located) {
+		return false
+	}
+	located.SetComponent(field, index, allowed)
+	return true
+}
+
+// SyntaxError is an error for text in a field that isn't valid syntax
+type SyntaxError struct {
+	Position
+	Suggestions
+	// Value is the unexpected text, it is empty if the field ended early
+	Value string
+	// Context describes where the text was, e.g. "after a number"
+	Context string
+	// Expected describes what is allowed instead
+	Expected string
+}
+
+// Error implements error and describes the problem
+func (s *SyntaxError) Error() string {
+	if s.Value == "" {
+		return fmt.Sprintf("%smissing value %s, expected %s", s.prefix(), s.Context, s.Expected)
+	}
+	return fmt.Sprintf("%s(%s) is unexpected %s, expected %s", s.prefix(), s.Value, s.Context, s.Expected)
+}
+
+// RangeError is an error for a value in a field that is outside of the values
+// that are allowed
+type RangeError struct {
+	Position
+	Suggestions
+	// What is the kind of value, e.g. "number" or "start of the range"
+	What string
+	// Value is the value that is out of range
+	Value string
+	// Min and Max are the bounds of the values that are allowed
+	Min, Max int
+}
+
+// Error implements error and describes the problem
+func (r *RangeError) Error() string {
+	return fmt.Sprintf("%s%s (%s) must be in range (%d-%d)", r.prefix(), r.What, r.Value, r.Min, r.Max)
+}
+
+// OrderError is an error for a range whose start is after its end, in a field
+// that doesn't allow ranges to wrap around
+type OrderError struct {
+	Position
+	Suggestions
+	// Start and End are the ends of the range as they were written
+	Start, End string
+}
+
+// Error implements error and describes the problem
+func (o *OrderError) Error() string {
+	return fmt.Sprintf("%sstart of the range (%s) must not be after the end (%s)", o.prefix(), o.Start, o.End)
+}
+
+// StepError is an error for a step that is outside of the steps that are
+// allowed in a field
+type StepError struct {
+	Position
+	Suggestions
+	// Value is the step that is out of range
+	Value string
+	// Min and Max are the bounds of the steps that are allowed
+	Min, Max int
+}
+
+// Error implements error and describes the problem
+func (s *StepError) Error() string {
+	return fmt.Sprintf("%sstep (%s) must be in range (%d-%d)", s.prefix(), s.Value, s.Min, s.Max)
+}
+
+// Errors is a list of errors, for when parsing carries on after an error so
+// that all of the problems in an expression can be reported at once. It
+// doesn't unwrap to the errors, as errors.As only looks inside a list of
+// errors from Go 1.20, so they are inspected by ranging over them.
+type Errors []error
+
+// Error implements error and describes each of the problems on its own line
+func (e Errors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// Append will append err to the errors, flattening it if it is also Errors
+func Append(errs Errors, err error) Errors {
+	var list Errors
+	if errors.As(err, &list) {
+		return append(errs, list...)
+	}
+	return append(errs, err)
+}
+
+// Sort will sort the errors that have a position by their offset within the
+// field, errors without a position are kept at the front
+func Sort(errs Errors) {
+	sort.SliceStable(errs, func(i, j int) bool {
+		return offset(errs[i]) < offset(errs[j])
+	})
+}
+
+func offset(err error) int {
+	var located Located
+	if !errors.As(err, &located) {
+		return -1
+	}
+	return located.Where().Offset
+}
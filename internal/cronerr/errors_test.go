@@ -0,0 +1,118 @@
+package cronerr_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+)
+
+func TestErrors_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name: "syntax",
+			err: &cronerr.SyntaxError{
+				Position: cronerr.Position{Field: "minute"},
+				Value:    "a",
+				Context:  "after a number",
+				Expected: "(,)",
+			},
+			expected: "(minute): (a) is unexpected after a number, expected (,)",
+		},
+		{
+			name:     "syntax missing value",
+			err:      &cronerr.SyntaxError{Context: "after (/)", Expected: "a number"},
+			expected: "missing value after (/), expected a number",
+		},
+		{
+			name: "range",
+			err: &cronerr.RangeError{
+				Position: cronerr.Position{Field: "hour"},
+				What:     "number",
+				Value:    "24",
+				Max:      23,
+			},
+			expected: "(hour): number (24) must be in range (0-23)",
+		},
+		{
+			name:     "step",
+			err:      &cronerr.StepError{Value: "0", Min: 1, Max: 60},
+			expected: "step (0) must be in range (1-60)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.err.Error(); got != test.expected {
+				t.Fatalf("expected (%s), got (%s)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestLocate(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", &cronerr.StepError{Value: "0"})
+	cronerr.Locate(err, 2, 1)
+	var stepErr *cronerr.StepError
+	if !errors.As(err, &stepErr) {
+		t.Fatal("expected a step error")
+	}
+	expected := cronerr.Position{Offset: 2, Length: 1}
+	if stepErr.Position != expected {
+		t.Fatalf("expected (%+v), got (%+v)", expected, stepErr.Position)
+	}
+	if cronerr.Locate(nil, 2, 1) != nil {
+		t.Fatal("expected no error")
+	}
+}
+
+func TestSetComponent(t *testing.T) {
+	err := &cronerr.RangeError{}
+	if !cronerr.SetComponent(err, "hour", 2, "a number (0-23) or (*)") {
+		t.Fatal("expected the component to be set")
+	}
+	expected := cronerr.Position{Field: "hour", Index: 2, Allowed: "a number (0-23) or (*)"}
+	if err.Position != expected {
+		t.Fatalf("expected (%+v), got (%+v)", expected, err.Position)
+	}
+	if cronerr.SetComponent(errors.New("an error"), "hour", 2, "") {
+		t.Fatal("expected the field not to be set")
+	}
+}
+
+func TestErrors(t *testing.T) {
+	var errs cronerr.Errors
+	errs = cronerr.Append(errs, &cronerr.StepError{Position: cronerr.Position{Offset: 6}, Value: "0", Max: 1})
+	errs = cronerr.Append(errs, cronerr.Errors{
+		errors.New("an error"),
+		&cronerr.RangeError{Position: cronerr.Position{Offset: 2}, What: "number", Value: "60", Max: 59},
+	})
+	cronerr.Sort(errs)
+	expected := "an error\nnumber (60) must be in range (0-59)\nstep (0) must be in range (0-1)"
+	if got := errs.Error(); got != expected {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+	if got := len(errs); got != 3 {
+		t.Fatalf("expected (3) errors, got (%d)", got)
+	}
+}
+
+func TestLocate_Suggestions(t *testing.T) {
+	err := cronerr.Suggest(
+		&cronerr.RangeError{What: "number", Value: "24", Max: 23},
+		cronerr.Suggestion{Length: 2, Replacement: "0"},
+	)
+	cronerr.Locate(err, 3, 2)
+	expected := []cronerr.Suggestion{{Offset: 3, Length: 2, Replacement: "0"}}
+	if got := cronerr.Suggested(err); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+	if got := cronerr.Suggested(errors.New("an error")); got != nil {
+		t.Fatalf("expected no suggestions, got (%+v)", got)
+	}
+}
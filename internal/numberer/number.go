@@ -1,11 +1,19 @@
 package numberer
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 )
 
 var (
+	// SecondFactory is a factory to produce valid second values
+	SecondFactory = Must(NewFactory("second", 0, 59))
+
 	// MinuteFactory is a factory to produce valid minute values
 	MinuteFactory = Must(NewFactory("minute", 0, 59))
 
@@ -15,11 +23,24 @@ var (
 	// DayOfMonthFactory is a factory to produce valid day of month values
 	DayOfMonthFactory = Must(NewFactory("dayOfMonth", 1, 31))
 
-	// MonthFactory is a factory to produce valid month values
-	MonthFactory = Must(NewFactory("month", 1, 12))
+	// MonthFactory is a factory to produce valid month values, it accepts the
+	// names of the months (JAN-DEC)
+	MonthFactory = Must(NewNamedFactory("month", 1, 12, []string{
+		"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC",
+	}))
+
+	// DayOfWeekFactory is a factory that can produce valid day of week values, it
+	// accepts the names of the days (SUN-SAT), and 7 as sunday
+	DayOfWeekFactory = Must(NewNamedFactory("dayOfWeek", 0, 6, []string{
+		"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT",
+	})).WithWrappingEnd(7)
 
-	// DayOfWeekFactory is a factory that can produce valid day of week values
-	DayOfWeekFactory = Must(NewFactory("dayOfWeek", 0, 6))
+	// QuartzDayOfWeekFactory is a factory that can produce valid day of week
+	// values from the numbers used by Quartz, where 1-7 is SUN-SAT. The values
+	// are produced as 0-6, the same as DayOfWeekFactory.
+	QuartzDayOfWeekFactory = Must(NewNamedFactory("dayOfWeek", 1, 7, []string{
+		"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT",
+	})).WithOffset(-1)
 )
 
 // Must will panic if an error is passed to it, used for factory variables
@@ -38,52 +59,321 @@ func NewFactory(name string, start, end int) (Factory, error) {
 		return Factory{}, fmt.Errorf("(%s) %w", name, err)
 	}
 	return Factory{
-		rnge: rnge,
 		name: name,
+		rnge: rnge,
 	}, nil
 }
 
+// NewNamedFactory will create a new factory for a given range, which will also
+// accept names in place of the numbers, the names are matched case
+// insensitively and are given in order from the start of the range
+func NewNamedFactory(name string, start, end int, names []string) (Factory, error) {
+	factory, err := NewFactory(name, start, end)
+	if err != nil {
+		return Factory{}, err
+	}
+	if len(names) != end-start+1 {
+		return Factory{}, fmt.Errorf("(%s) expected (%d) names, got (%d)", name, end-start+1, len(names))
+	}
+	factory.names = make(map[string]int, len(names))
+	factory.nameOrder = make([]string, 0, len(names))
+	factory.nameRange = fmt.Sprintf("%s-%s", strings.ToUpper(names[0]), strings.ToUpper(names[len(names)-1]))
+	for i, valueName := range names {
+		factory.names[strings.ToUpper(valueName)] = start + i
+		factory.nameOrder = append(factory.nameOrder, strings.ToUpper(valueName))
+	}
+	return factory, nil
+}
+
 // Factory is a type that can create Numberers for different strings
 type Factory struct {
-	rnge Range
-	name string
+	name       string
+	rnge       Range
+	names      map[string]int
+	nameOrder  []string
+	nameRange  string
+	offset     int
+	wrapToEnd  int
+	wrapAround bool
+}
+
+// WithWrapAround will return a copy of the factory that accepts ranges where
+// the start is after the end, the range wraps around from the end of the
+// factory's range to the start e.g. (22-2) for hours is 22 23 0 1 2
+func (f Factory) WithWrapAround() Factory {
+	f.wrapAround = true
+	return f
+}
+
+// WithWrappingEnd will return a copy of the factory that accepts values up to
+// end, which is past the end of its range. These values wrap around to the
+// start of its range, e.g. 7 is the same as 0 for the days of the week (0-6).
+func (f Factory) WithWrappingEnd(end int) Factory {
+	f.wrapToEnd = end
+	return f
+}
+
+// WithOffset will return a copy of the factory that adds the offset to all of
+// the values that it produces, after they have been validated against its
+// range
+func (f Factory) WithOffset(offset int) Factory {
+	f.offset = offset
+	return f
 }
 
 // Number will create a Number Numberer from a string, and validate that
 // it is within the range of the factory (along with validating that the
-// input is an int)
+// input is an int, or a name the factory accepts)
 func (f Factory) Number(numstr string) (Number, error) {
-	num, err := strconv.ParseInt(numstr, 10, 32)
+	num, err := f.parse(numstr)
 	if err != nil {
-		return 0, fmt.Errorf("(%s) failed to parse (%s) as int got (%w)", f.name, numstr, err)
+		return 0, err
 	}
-	if int(num) < f.rnge.Start || int(num) > f.rnge.End {
-		return 0, fmt.Errorf("(%s) number (%d) must be in range (%d-%d)", f.name, num, f.rnge.Start, f.rnge.End)
+	if int(num) < f.rnge.Start || int(num) > f.end() {
+		return 0, f.rangeError("number", numstr)
 	}
-	return Number(num), nil
+	return Number(f.cycle().wrap(int(num)) + f.offset), nil
 }
 
 // Range will create a range from the given strings, validating that the
 // range that they within the range of the factory (along with validating
-// that the inputs is an int)
+// that the inputs is an int, or a name the factory accepts)
 func (f Factory) Range(startString, endString string) (Range, error) {
-	start, err := strconv.ParseInt(startString, 10, 32)
+	start, err := f.parse(startString)
 	if err != nil {
-		return Range{}, fmt.Errorf("(%s) failed to parse (%s) as int got (%w)", f.name, startString, err)
+		return Range{}, err
 	}
-	end, err := strconv.ParseInt(endString, 10, 32)
+	end, err := f.parse(endString)
 	if err != nil {
-		return Range{}, fmt.Errorf("(%s) failed to parse (%s) as int got (%w)", f.name, endString, err)
+		return Range{}, err
+	}
+	if int(start) < f.rnge.Start || int(start) > f.end() {
+		return Range{}, f.rangeError("start of the range", startString)
 	}
-	if int(start) < f.rnge.Start || int(end) > f.rnge.End {
-		return Range{}, fmt.Errorf("(%s) range (%d - %d) must be in range (%d - %d)", f.name, start, end, f.rnge.Start, f.rnge.End)
+	if int(end) < f.rnge.Start || int(end) > f.end() {
+		return Range{}, f.rangeError("end of the range", endString)
+	}
+	cycle := f.cycle()
+	if f.wrapAround && start > end {
+		end += int64(cycle.Size)
+	}
+	if start > end {
+		return Range{}, cronerr.Suggest(
+			&cronerr.OrderError{Start: startString, End: endString},
+			f.suggestSplit(startString, endString),
+		)
+	}
+	rnge := Range{Start: int(start) + f.offset, End: int(end) + f.offset}
+	if int(end) > f.rnge.End {
+		rnge.Min, rnge.Size = cycle.Min+f.offset, cycle.Size
+	}
+	return rnge, nil
+}
+
+// cycle returns a range that wraps values past the end of the range of the
+// factory around to its start
+func (f Factory) cycle() Range {
+	return Range{
+		Start: f.rnge.Start,
+		End:   f.rnge.End,
+		Min:   f.rnge.Start,
+		Size:  f.rnge.End - f.rnge.Start + 1,
 	}
-	return NewRange(int(start), int(end))
+}
+
+// From will create a range from the given string to the end of the range of
+// the factory, as is used by a step from a single value e.g. (5/15)
+func (f Factory) From(startString string) (Range, error) {
+	start, err := f.parse(startString)
+	if err != nil {
+		return Range{}, err
+	}
+	end := f.rnge.End
+	if int(start) > end {
+		// values past the end of the range, such as 7 for sunday, only
+		// step over themselves
+		end = int(start)
+	}
+	return f.Range(startString, strconv.Itoa(end))
+}
+
+// rangeError returns an error for a value that is outside of the range of the
+// factory, with a suggested fix for the common mistakes
+func (f Factory) rangeError(what, value string) error {
+	return cronerr.Suggest(&cronerr.RangeError{
+		What:  what,
+		Value: value,
+		Min:   f.rnge.Start,
+		Max:   f.end(),
+	}, f.suggest(what, value)...)
+}
+
+// zeroNames describe what 0 is for the components that start at 0, where one
+// past the end is a common mistake for 0 e.g. 24 for midnight
+var zeroNames = map[string]string{
+	"second": "the start of the minute",
+	"minute": "the start of the hour",
+	"hour":   "midnight",
+}
+
+// suggest returns the fixes for a value that is outside of the range of the
+// factory, if it is just past either end of it
+func (f Factory) suggest(what, value string) []cronerr.Suggestion {
+	num, err := strconv.Atoi(value)
+	if err != nil {
+		return nil
+	}
+	var replacement int
+	switch zeroName, ok := zeroNames[f.name]; {
+	case num == f.end()+1 && what == "end of the range":
+		replacement = f.end()
+	case num == f.end()+1 && ok:
+		return []cronerr.Suggestion{{
+			Length:      len(value),
+			Replacement: "0",
+			Message:     fmt.Sprintf("did you mean 0 (%s)?", zeroName),
+		}}
+	case num == f.rnge.Start-1:
+		replacement = f.rnge.Start
+	default:
+		return nil
+	}
+	message := fmt.Sprintf("did you mean %d?", replacement)
+	if name, ok := f.nameOf(replacement); ok {
+		message = fmt.Sprintf("did you mean %d (%s)?", replacement, name)
+	}
+	return []cronerr.Suggestion{{
+		Length:      len(value),
+		Replacement: strconv.Itoa(replacement),
+		Message:     message,
+	}}
+}
+
+// suggestSplit returns the fix for a range that is backwards, which splits it
+// into the range up to the end of the factory and the range from its start,
+// e.g. (22-23,0-2) for (22-2)
+func (f Factory) suggestSplit(startString, endString string) cronerr.Suggestion {
+	named := unicode.IsLetter(rune(startString[0]))
+	bound := func(num int) string {
+		if name, ok := f.nameOf(num); ok && named {
+			return name
+		}
+		return strconv.Itoa(num)
+	}
+	first := startString + "-" + bound(f.rnge.End)
+	if start, _ := f.parse(startString); int(start) >= f.rnge.End {
+		first = startString
+	}
+	second := bound(f.rnge.Start) + "-" + endString
+	if end, _ := f.parse(endString); int(end) <= f.rnge.Start {
+		second = endString
+	}
+	replacement := first + "," + second
+	return cronerr.Suggestion{
+		Length:      len(startString) + len("-") + len(endString),
+		Replacement: replacement,
+		Message:     fmt.Sprintf("did you mean %s? or allow ranges that wrap around", replacement),
+	}
+}
+
+// suggestName returns the fix for a name that isn't known, if it starts with
+// one of the names that are, e.g. MONDAY for MON. The names are checked in the
+// order that they were given, and the longest that it starts with is
+// suggested, so the suggestion doesn't change from run to run.
+func (f Factory) suggestName(value string) []cronerr.Suggestion {
+	suggested := ""
+	for _, name := range f.nameOrder {
+		if len(value) > len(name) && len(name) > len(suggested) && strings.HasPrefix(strings.ToUpper(value), name) {
+			suggested = name
+		}
+	}
+	if suggested == "" {
+		return nil
+	}
+	return []cronerr.Suggestion{{
+		Length:      len(value),
+		Replacement: suggested,
+		Message:     fmt.Sprintf("did you mean %s?", suggested),
+	}}
+}
+
+// nameOf returns the name of a number, if the factory accepts names
+func (f Factory) nameOf(num int) (string, bool) {
+	for _, name := range f.nameOrder {
+		if f.names[name] == num {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// HasNames tells you whether the factory accepts names as well as numbers
+func (f Factory) HasNames() bool {
+	return f.names != nil
+}
+
+// Start returns the smallest value that the factory accepts
+func (f Factory) Start() int {
+	return f.rnge.Start
+}
+
+// end returns the largest value that the factory accepts
+func (f Factory) end() int {
+	if f.wrapToEnd > f.rnge.End {
+		return f.wrapToEnd
+	}
+	return f.rnge.End
+}
+
+// parse will parse a number, or if the factory accepts names, a name
+func (f Factory) parse(numstr string) (int64, error) {
+	if numstr == "" {
+		return 0, &cronerr.SyntaxError{Context: "in the field", Expected: f.expected()}
+	}
+	if !unicode.IsLetter(rune(numstr[0])) {
+		num, err := strconv.ParseInt(numstr, 10, 32)
+		if errors.Is(err, strconv.ErrRange) {
+			return 0, f.rangeError("number", numstr)
+		}
+		if err != nil {
+			return 0, &cronerr.SyntaxError{Value: numstr, Context: "in the field", Expected: f.expected()}
+		}
+		return num, nil
+	}
+	if f.names == nil {
+		return 0, &cronerr.SyntaxError{Value: numstr, Context: "in the field", Expected: f.expected()}
+	}
+	num, ok := f.names[strings.ToUpper(numstr)]
+	if !ok {
+		return 0, cronerr.Suggest(
+			&cronerr.SyntaxError{Value: numstr, Context: "as a name", Expected: f.expected()},
+			f.suggestName(numstr)...,
+		)
+	}
+	return int64(num), nil
+}
+
+// expected describes the values that the factory accepts
+func (f Factory) expected() string {
+	if f.names == nil {
+		return fmt.Sprintf("a number (%d-%d)", f.rnge.Start, f.end())
+	}
+	return fmt.Sprintf("a number (%d-%d) or a name (%s)", f.rnge.Start, f.end(), f.nameRange)
+}
+
+// Descriptions describe the values that the factory accepts, e.g. a number
+// (1-12) and a name (JAN-DEC)
+func (f Factory) Descriptions() []string {
+	descriptions := []string{fmt.Sprintf("a number (%d-%d)", f.rnge.Start, f.end())}
+	if f.names != nil {
+		descriptions = append(descriptions, fmt.Sprintf("a name (%s)", f.nameRange))
+	}
+	return descriptions
 }
 
 // Any will return a Numberer that will return the entire range of numbers
 func (f Factory) Any() Any {
-	return f.rnge.Numbers()
+	return Range{Start: f.rnge.Start + f.offset, End: f.rnge.End + f.offset}.Numbers()
 }
 
 // Number is a Numberer that will return a single number
@@ -107,21 +397,36 @@ func NewRange(start, end int) (Range, error) {
 	}, nil
 }
 
-// Range is a Numberer that will return all of the numbers in a given range
+// Range is a Numberer that will return all of the numbers in a given range,
+// if Size is set, the numbers wrap around the cycle of that size starting at
+// Min, e.g. (5-7) with a Min of 0 and a Size of 7 is 5 6 0
 type Range struct {
 	Start, End int
+	Min, Size  int
 }
 
 // Numbers implements Numberer that will return all of the numbers between
 // the start and end values
 func (r Range) Numbers() []int {
-	numbers := make([]int, 0, r.End-r.Start)
+	numbers := make([]int, 0, r.End-r.Start+1)
 	for i := r.Start; i <= r.End; i++ {
-		numbers = append(numbers, i)
+		numbers = append(numbers, r.wrap(i))
 	}
 	return numbers
 }
 
+// wrap will wrap a number around the cycle of the range, if it has one
+func (r Range) wrap(number int) int {
+	if r.Size == 0 {
+		return number
+	}
+	wrapped := (number - r.Min) % r.Size
+	if wrapped < 0 {
+		wrapped += r.Size
+	}
+	return r.Min + wrapped
+}
+
 // Any is a type that can be filled with all the numbers from a range
 type Any []int
 
@@ -135,5 +440,6 @@ func (a Any) Numbers() []int {
 type Provider interface {
 	Number(numstr string) (Number, error)
 	Range(startString, endString string) (Range, error)
+	From(startString string) (Range, error)
 	Any() Any
 }
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 	"github.com/alistairjudson/cronparse/internal/numberer"
 )
 
@@ -20,6 +21,20 @@ func TestRange_Numbers(t *testing.T) {
 	}
 }
 
+func TestRange_NumbersWraps(t *testing.T) {
+	rng := numberer.Range{
+		Start: 5,
+		End:   8,
+		Min:   0,
+		Size:  7,
+	}
+	gotRange := rng.Numbers()
+	expectedRange := []int{5, 6, 0, 1}
+	if !reflect.DeepEqual(expectedRange, gotRange) {
+		t.Fatalf("expected (%+v), got (%+v)", expectedRange, gotRange)
+	}
+}
+
 func TestNewRangeFails(t *testing.T) {
 	_, err := numberer.NewRange(10, 0)
 	if err == nil {
@@ -146,3 +161,398 @@ func TestMust(t *testing.T) {
 	}()
 	numberer.Must(numberer.Factory{}, errors.New("an error"))
 }
+
+func TestNewNamedFactoryFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		start, end int
+		names      []string
+	}{
+		{
+			name:  "invalid range",
+			start: 10,
+			end:   0,
+		},
+		{
+			name:  "wrong number of names",
+			start: 0,
+			end:   2,
+			names: []string{"A", "B"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := numberer.NewNamedFactory("test", test.start, test.end, test.names); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestFactory_NumberNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		factory  numberer.Factory
+		value    string
+		expected numberer.Number
+	}{
+		{
+			name:     "month",
+			factory:  numberer.MonthFactory,
+			value:    "JAN",
+			expected: 1,
+		},
+		{
+			name:     "month lower case",
+			factory:  numberer.MonthFactory,
+			value:    "dec",
+			expected: 12,
+		},
+		{
+			name:     "day of week",
+			factory:  numberer.DayOfWeekFactory,
+			value:    "Sun",
+			expected: 0,
+		},
+		{
+			name:     "day of week number",
+			factory:  numberer.DayOfWeekFactory,
+			value:    "6",
+			expected: 6,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.factory.Number(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.expected {
+				t.Fatalf("expected (%d), got (%d)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestFactory_NumberNamesFails(t *testing.T) {
+	tests := []struct {
+		name    string
+		factory numberer.Factory
+		value   string
+	}{
+		{
+			name:    "names not supported",
+			factory: numberer.MinuteFactory,
+			value:   "JAN",
+		},
+		{
+			name:    "unknown name",
+			factory: numberer.MonthFactory,
+			value:   "JANUARY",
+		},
+		{
+			name:    "name from another field",
+			factory: numberer.DayOfWeekFactory,
+			value:   "JAN",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.factory.Number(test.value); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestFactory_RangeNames(t *testing.T) {
+	rng, err := numberer.DayOfWeekFactory.Range("MON", "fri")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedNumbers := []int{1, 2, 3, 4, 5}
+	if !reflect.DeepEqual(expectedNumbers, rng.Numbers()) {
+		t.Fatalf("expected numbers (%+v), got numbers (%+v)", expectedNumbers, rng.Numbers())
+	}
+	if _, err := numberer.DayOfWeekFactory.Range("MON", "FRIDAY"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestFactory_WithOffset(t *testing.T) {
+	factory := numberer.QuartzDayOfWeekFactory
+	if _, err := factory.Number("0"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	number, err := factory.Number("SAT")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number != 6 {
+		t.Fatalf("expected (6), got (%d)", number)
+	}
+	rnge, err := factory.Range("2", "6")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedNumbers := []int{1, 2, 3, 4, 5}
+	if gotNumbers := rnge.Numbers(); !reflect.DeepEqual(expectedNumbers, gotNumbers) {
+		t.Fatalf("expected numbers (%+v), got numbers (%+v)", expectedNumbers, gotNumbers)
+	}
+	expectedNumbers = []int{0, 1, 2, 3, 4, 5, 6}
+	if gotNumbers := factory.Any().Numbers(); !reflect.DeepEqual(expectedNumbers, gotNumbers) {
+		t.Fatalf("expected numbers (%+v), got numbers (%+v)", expectedNumbers, gotNumbers)
+	}
+}
+
+func TestFactory_WithWrappingEnd(t *testing.T) {
+	tests := []struct {
+		name             string
+		startStr, endStr string
+		expectedNumbers  []int
+	}{
+		{
+			name:            "number",
+			startStr:        "7",
+			expectedNumbers: []int{0},
+		},
+		{
+			name:            "range to the end",
+			startStr:        "5",
+			endStr:          "7",
+			expectedNumbers: []int{5, 6, 0},
+		},
+		{
+			name:            "name range to the end",
+			startStr:        "MON",
+			endStr:          "7",
+			expectedNumbers: []int{1, 2, 3, 4, 5, 6, 0},
+		},
+		{
+			name:            "range within the end",
+			startStr:        "1",
+			endStr:          "3",
+			expectedNumbers: []int{1, 2, 3},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				num interface{ Numbers() []int }
+				err error
+			)
+			if test.endStr == "" {
+				num, err = numberer.DayOfWeekFactory.Number(test.startStr)
+			} else {
+				num, err = numberer.DayOfWeekFactory.Range(test.startStr, test.endStr)
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotNumbers := num.Numbers(); !reflect.DeepEqual(test.expectedNumbers, gotNumbers) {
+				t.Fatalf("expected numbers (%+v), got numbers (%+v)", test.expectedNumbers, gotNumbers)
+			}
+		})
+	}
+}
+
+func TestFactory_WithWrappingEndFails(t *testing.T) {
+	if _, err := numberer.DayOfWeekFactory.Number("8"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if _, err := numberer.DayOfWeekFactory.Range("7", "1"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestFactory_WithWrapAround(t *testing.T) {
+	tests := []struct {
+		name             string
+		factory          numberer.Factory
+		startStr, endStr string
+		expectedNumbers  []int
+	}{
+		{
+			name:            "hours",
+			factory:         numberer.HourFactory.WithWrapAround(),
+			startStr:        "22",
+			endStr:          "2",
+			expectedNumbers: []int{22, 23, 0, 1, 2},
+		},
+		{
+			name:            "months start at one",
+			factory:         numberer.MonthFactory.WithWrapAround(),
+			startStr:        "NOV",
+			endStr:          "FEB",
+			expectedNumbers: []int{11, 12, 1, 2},
+		},
+		{
+			name:            "days of the week from seven",
+			factory:         numberer.DayOfWeekFactory.WithWrapAround(),
+			startStr:        "7",
+			endStr:          "2",
+			expectedNumbers: []int{0, 1, 2},
+		},
+		{
+			name:            "quartz days of the week",
+			factory:         numberer.QuartzDayOfWeekFactory.WithWrapAround(),
+			startStr:        "FRI",
+			endStr:          "MON",
+			expectedNumbers: []int{5, 6, 0, 1},
+		},
+		{
+			name:            "not wrapped",
+			factory:         numberer.HourFactory.WithWrapAround(),
+			startStr:        "2",
+			endStr:          "4",
+			expectedNumbers: []int{2, 3, 4},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rnge, err := test.factory.Range(test.startStr, test.endStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotNumbers := rnge.Numbers(); !reflect.DeepEqual(test.expectedNumbers, gotNumbers) {
+				t.Fatalf("expected numbers (%+v), got numbers (%+v)", test.expectedNumbers, gotNumbers)
+			}
+		})
+	}
+}
+
+func TestFactory_RangeFailsWithoutWrapAround(t *testing.T) {
+	if _, err := numberer.HourFactory.Range("22", "2"); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestFactory_From(t *testing.T) {
+	tests := []struct {
+		name            string
+		factory         numberer.Factory
+		startStr        string
+		expectedNumbers []int
+	}{
+		{
+			name:            "minute",
+			factory:         numberer.MinuteFactory,
+			startStr:        "55",
+			expectedNumbers: []int{55, 56, 57, 58, 59},
+		},
+		{
+			name:            "name",
+			factory:         numberer.MonthFactory,
+			startStr:        "OCT",
+			expectedNumbers: []int{10, 11, 12},
+		},
+		{
+			name:            "past the end of the range",
+			factory:         numberer.DayOfWeekFactory,
+			startStr:        "7",
+			expectedNumbers: []int{0},
+		},
+		{
+			name:            "offset",
+			factory:         numberer.QuartzDayOfWeekFactory,
+			startStr:        "6",
+			expectedNumbers: []int{5, 6},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rnge, err := test.factory.From(test.startStr)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotNumbers := rnge.Numbers(); !reflect.DeepEqual(test.expectedNumbers, gotNumbers) {
+				t.Fatalf("expected numbers (%+v), got numbers (%+v)", test.expectedNumbers, gotNumbers)
+			}
+		})
+	}
+}
+
+func TestFactory_FromFails(t *testing.T) {
+	for _, startStr := range []string{"zzz", "60", "-1"} {
+		t.Run(startStr, func(t *testing.T) {
+			if _, err := numberer.MinuteFactory.From(startStr); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestFactory_Suggestions(t *testing.T) {
+	tests := []struct {
+		name     string
+		parse    func() error
+		expected []cronerr.Suggestion
+	}{
+		{
+			name: "one past the end of the hours",
+			parse: func() error {
+				_, err := numberer.HourFactory.Number("24")
+				return err
+			},
+			expected: []cronerr.Suggestion{{Length: 2, Replacement: "0", Message: "did you mean 0 (midnight)?"}},
+		},
+		{
+			name: "one past the end of a range",
+			parse: func() error {
+				_, err := numberer.MinuteFactory.Range("30", "60")
+				return err
+			},
+			expected: []cronerr.Suggestion{{Length: 2, Replacement: "59", Message: "did you mean 59?"}},
+		},
+		{
+			name: "one before the start of the months",
+			parse: func() error {
+				_, err := numberer.MonthFactory.Number("0")
+				return err
+			},
+			expected: []cronerr.Suggestion{{Length: 1, Replacement: "1", Message: "did you mean 1 (JAN)?"}},
+		},
+		{
+			name: "full name",
+			parse: func() error {
+				_, err := numberer.MonthFactory.Number("september")
+				return err
+			},
+			expected: []cronerr.Suggestion{{Length: 9, Replacement: "SEP", Message: "did you mean SEP?"}},
+		},
+		{
+			name: "longest name that it starts with",
+			parse: func() error {
+				factory := numberer.Must(numberer.NewNamedFactory("shift", 1, 4, []string{"E", "EARLY", "EA", "LATE"}))
+				_, err := factory.Number("earlyish")
+				return err
+			},
+			expected: []cronerr.Suggestion{{Length: 8, Replacement: "EARLY", Message: "did you mean EARLY?"}},
+		},
+		{
+			name: "far out of range",
+			parse: func() error {
+				_, err := numberer.DayOfMonthFactory.Number("40")
+				return err
+			},
+		},
+		{
+			name: "one past the end of the days of the week",
+			parse: func() error {
+				_, err := numberer.DayOfWeekFactory.Number("8")
+				return err
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.parse()
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if got := cronerr.Suggested(err); !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
@@ -1,12 +1,14 @@
 package parse
 
 import (
-	"sort"
-
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 	"github.com/alistairjudson/cronparse/internal/numberer"
 )
 
 var (
+	// SecondParser is a parser to parse the second component of a cron expression
+	SecondParser = NewParser(numberer.SecondFactory)
+
 	// MinuteParser is a parser to parse the minute component of a cron expression
 	MinuteParser = NewParser(numberer.MinuteFactory)
 
@@ -28,7 +30,9 @@ func NewParser(provider numberer.Provider) Parser {
 	return NewAdapter(
 		NewStepNumbererFactory(
 			NewBaseNumbererFactory(provider),
+			len(provider.Any()),
 		),
+		SyntaxOf(provider),
 	)
 }
 
@@ -39,36 +43,76 @@ type Parser interface {
 }
 
 // NewAdapter will return you a new instance of an Adapter, with a
-// Partitioner as its PartsFactory
-func NewAdapter(provider NumbererProvider) Adapter {
+// Partitioner for a field with the syntax as its PartsFactory
+func NewAdapter(provider NumbererProvider, syntax Syntax) Adapter {
 	return Adapter{
-		PartsFactory:    NewPartitioner(),
+		PartsFactory:    NewPartitioner(syntax),
 		NumbererFactory: provider,
 	}
 }
 
+// SyntaxOfParser returns the syntax that a parser accepts, which is AnySyntax
+// for parsers that aren't an Adapter with a Partitioner
+func SyntaxOfParser(parser Parser) Syntax {
+	if adapter, ok := parser.(Adapter); ok {
+		if partitioner, ok := adapter.PartsFactory.(Partitioner); ok {
+			return partitioner.Syntax
+		}
+	}
+	return AnySyntax
+}
+
+// WithAllErrors will return a copy of the parser that carries on after an
+// error, returning all of the errors in the field as cronerr.Errors sorted
+// by where they are. Parsers that aren't an Adapter are returned as they are.
+func WithAllErrors(parser Parser) Parser {
+	adapter, ok := parser.(Adapter)
+	if !ok {
+		return parser
+	}
+	if partitioner, ok := adapter.PartsFactory.(Partitioner); ok {
+		adapter.PartsFactory = NewAllErrorsPartitioner(partitioner.Syntax)
+	}
+	adapter.AllErrors = true
+	return adapter
+}
+
 // Adapter is a type that can adapt the PartsProvider and the Provider
-// into a Parser
+// into a Parser, if AllErrors is set it will parse all of the parts that it
+// can, returning all of the errors rather than just the first
 type Adapter struct {
 	PartsFactory    PartsProvider
 	NumbererFactory NumbererProvider
+	AllErrors       bool
 }
 
 // Parse implements Parser and will parse the statement into a Numberer, by
 // parsing it into parts, and then into an AggregateNumberer
 func (a Adapter) Parse(statement string) (Numberer, error) {
 	parts, err := a.PartsFactory.Parts(statement)
-	if err != nil {
+	if err != nil && !a.AllErrors {
 		return nil, err
 	}
+	var errs cronerr.Errors
+	if err != nil {
+		errs = cronerr.Append(errs, err)
+	}
 	aggregate := make(AggregateNumberer, 0, len(parts))
 	for _, part := range parts {
 		partNumberer, err := a.NumbererFactory.Numberer(part)
-		if err != nil {
+		if err != nil && !a.AllErrors {
 			return nil, err
 		}
+		if err != nil {
+			errs = cronerr.Append(errs, err)
+			continue
+		}
 		aggregate = append(aggregate, partNumberer)
 	}
+	if len(errs) > 0 {
+		cronerr.Sort(errs)
+		return nil, errs
+	}
 	return aggregate, nil
 }
 
@@ -79,16 +123,27 @@ type AggregateNumberer []Numberer
 // Numbers implements the Numberer interface and will produce an ordered,
 // de-duplicated list of all of the numbers contained within the AggregateNumberer
 func (a AggregateNumberer) Numbers() []int {
-	numberSet := map[int]struct{}{}
+	return a.Set().Values()
+}
+
+// Set returns all of the numbers contained within the AggregateNumberer as a
+// Set, so that they can be looked up in constant time
+func (a AggregateNumberer) Set() Set {
+	numbers := make([]int, 0)
 	for _, unaggregatedNumberer := range a {
-		for _, number := range unaggregatedNumberer.Numbers() {
-			numberSet[number] = struct{}{}
-		}
+		numbers = append(numbers, unaggregatedNumberer.Numbers()...)
 	}
-	numbers := make([]int, 0, len(numberSet))
-	for number := range numberSet {
-		numbers = append(numbers, number)
+	return NewSet(numbers...)
+}
+
+// DateMatchers returns all of the contained numberers that are DateMatchers,
+// their values depend on the date so they aren't included in Numbers
+func (a AggregateNumberer) DateMatchers() []DateMatcher {
+	var matchers []DateMatcher
+	for _, unaggregatedNumberer := range a {
+		if matcher, ok := unaggregatedNumberer.(DateMatcher); ok {
+			matchers = append(matchers, matcher)
+		}
 	}
-	sort.Ints(numbers)
-	return numbers
+	return matchers
 }
@@ -5,6 +5,7 @@ import (
 	"reflect"
 	"testing"
 
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 	"github.com/alistairjudson/cronparse/internal/parse"
 )
 
@@ -20,7 +21,7 @@ func TestAdapter_ParseFailsPartsFactory(t *testing.T) {
 	spp := stubPartsProvider{parts: func(input string) (parts []parse.Part, err error) {
 		return nil, errors.New("an error")
 	}}
-	adapter := parse.NewAdapter(stubParseNumbererProvider{})
+	adapter := parse.NewAdapter(stubParseNumbererProvider{}, parse.AnySyntax)
 	adapter.PartsFactory = spp
 	_, err := adapter.Parse("foo bar baz")
 	if err == nil {
@@ -33,7 +34,7 @@ func TestAdapter_ParseFailsNumbererFactory(t *testing.T) {
 	spnp := stubParseNumbererProvider{numberer: func(parts parse.Part) (numberer parse.Numberer, err error) {
 		return nil, expectedErr
 	}}
-	adapter := parse.NewAdapter(spnp)
+	adapter := parse.NewAdapter(spnp, parse.AnySyntax)
 	_, err := adapter.Parse("0-59/15")
 	if !errors.Is(err, expectedErr) {
 		t.Fatalf("expected error to be (%s), got (%s)", expectedErr, err)
@@ -52,3 +53,47 @@ func TestAdapter_ParseSucceeds(t *testing.T) {
 		t.Fatalf("expected numbers to be (%+v), got (%+v)", expectedNumbers, gotNumbers)
 	}
 }
+
+func TestWithAllErrors_Parse(t *testing.T) {
+	_, err := parse.WithAllErrors(parse.MinuteParser).Parse("5a,1,60,*/0")
+	errs, ok := err.(cronerr.Errors)
+	if !ok {
+		t.Fatalf("expected errors, got (%v)", err)
+	}
+	expected := []string{
+		"(a) is unexpected after a number, expected (,), (-) or (/)",
+		"number (60) must be in range (0-59)",
+		"step (0) must be in range (1-60)",
+	}
+	got := make([]string, 0, len(errs))
+	for _, err := range errs {
+		got = append(got, err.Error())
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
+
+func TestWithAllErrors_ParseSucceeds(t *testing.T) {
+	numberer, err := parse.WithAllErrors(parse.HourParser).Parse("1,2-3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []int{1, 2, 3}
+	if got := numberer.Numbers(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
+
+func TestWithAllErrors_NotAnAdapter(t *testing.T) {
+	parser := stubParser{}
+	if got := parse.WithAllErrors(parser); got != parser {
+		t.Fatalf("expected (%+v), got (%+v)", parser, got)
+	}
+}
+
+type stubParser struct{}
+
+func (s stubParser) Parse(input string) (parse.Numberer, error) {
+	return nil, errors.New("an error")
+}
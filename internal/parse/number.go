@@ -4,6 +4,7 @@ import (
 	"errors"
 	"strconv"
 
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 	"github.com/alistairjudson/cronparse/internal/numberer"
 )
 
@@ -37,27 +38,68 @@ type BaseNumbererFactory struct {
 func (b BaseNumbererFactory) Numberer(parts Part) (Numberer, error) {
 	types := parts.Types()
 	switch {
+	case types.Equals(TokenTypeNoSpecific):
+		// (?) means the same as (*) in the days of Quartz expressions, so
+		// it is a common mistake in the other fields, and in standard ones
+		return nil, cronerr.Suggest(
+			unexpected(parts[0], "in this field", SyntaxOf(b.Factory).item()),
+			cronerr.Suggestion{
+				Offset:      parts[0].Pos,
+				Length:      len(parts[0].Value),
+				Replacement: "*",
+				Message:     "(?) is only allowed in the day of month and day of week of Quartz expressions, did you mean (*)?",
+			},
+		)
+	case types.ContainsSpecial():
+		return nil, unexpected(parts.special(), "in this field", SyntaxOf(b.Factory).item())
 	case types.StartsWith(TokenTypeAny):
 		return b.Factory.Any(), nil
 	case types.Contains(TokenTypeDash):
-		return b.Factory.Range(parts[0].Value, parts[2].Value)
-	case types.StartsWith(TokenTypeNumber) && !types.Contains(TokenTypeDash):
-		return b.Factory.Number(parts[0].Value)
+		// the ends of the range are checked on their own first, so that
+		// the error is for the end that is wrong
+		if _, err := b.Factory.Number(parts[0].Value); err != nil {
+			return nil, locate(err, parts[0], parts[0])
+		}
+		if _, err := b.Factory.Number(parts[2].Value); err != nil {
+			// the start is valid, so the range describes what is wrong with
+			// the end, as the end of the range
+			if _, rangeErr := b.Factory.Range(parts[0].Value, parts[2].Value); rangeErr != nil {
+				err = rangeErr
+			}
+			return nil, locate(err, parts[2], parts[2])
+		}
+		rnge, err := b.Factory.Range(parts[0].Value, parts[2].Value)
+		var order *cronerr.OrderError
+		if errors.As(err, &order) && types.Contains(TokenTypeSlash) {
+			// splitting the range would change what the step applies to
+			order.Suggestions = nil
+		}
+		return rnge, locate(err, parts[0], parts[2])
+	case types.StartsWith(TokenTypeNumber, TokenTypeName) && types.Contains(TokenTypeSlash):
+		// a step from a single value runs to the end of the field, e.g. for
+		// minutes (5/15) is the same as (5-59/15)
+		rnge, err := b.Factory.From(parts[0].Value)
+		return rnge, locate(err, parts[0], parts[0])
+	case types.StartsWith(TokenTypeNumber, TokenTypeName) && !types.Contains(TokenTypeDash):
+		number, err := b.Factory.Number(parts[0].Value)
+		return number, locate(err, parts[0], parts[0])
 	}
 	return nil, errors.New("numberer does not match any valid patterns")
 }
 
 // NewStepNumbererFactory will create a new instance of StepNumbererFactory wrapping a base
-// factory
-func NewStepNumbererFactory(base NumbererProvider) StepNumbererFactory {
+// factory, the steps are limited to maxStep, which is usually the width of the field
+func NewStepNumbererFactory(base NumbererProvider, maxStep int) StepNumbererFactory {
 	return StepNumbererFactory{
-		Base: base,
+		Base:    base,
+		MaxStep: maxStep,
 	}
 }
 
 // StepNumbererFactory is a decorator of a Provider
 type StepNumbererFactory struct {
-	Base NumbererProvider
+	Base    NumbererProvider
+	MaxStep int
 }
 
 // Numberer implements Provider and will return a StepNumberer if the
@@ -71,15 +113,18 @@ func (s StepNumbererFactory) Numberer(part Part) (Numberer, error) {
 	if err != nil {
 		return nil, err
 	}
-	return NewStepNumberer(base, part[len(part)-1].Value)
+	step := part[len(part)-1]
+	stepNumberer, err := NewStepNumberer(base, step.Value, s.MaxStep)
+	return stepNumberer, locate(err, step, step)
 }
 
 // NewStepNumberer will return a Numberer that will only output numbers in steps
-// from the Numberer that it decorates
-func NewStepNumberer(base Numberer, stepStr string) (Numberer, error) {
+// from the Numberer that it decorates, the step must be in the range 1 to
+// maxStep
+func NewStepNumberer(base Numberer, stepStr string, maxStep int) (Numberer, error) {
 	step, err := strconv.ParseInt(stepStr, 10, 32)
-	if err != nil {
-		return nil, err
+	if err != nil || step < 1 || int(step) > maxStep {
+		return nil, &cronerr.StepError{Value: stepStr, Min: 1, Max: maxStep}
 	}
 	return StepNumberer{
 		Base: base,
@@ -107,3 +152,30 @@ func (s StepNumberer) Numbers() []int {
 	}
 	return output
 }
+
+// locate will set the position of err, if it has one, to cover the tokens
+// from first to last
+func locate(err error, first, last Token) error {
+	return cronerr.Locate(err, first.Pos, last.Pos+len(last.Value)-first.Pos)
+}
+
+// unexpected returns a syntax error for a token that is valid syntax, but isn't
+// allowed where it is
+func unexpected(token Token, context, expected string) error {
+	return &cronerr.SyntaxError{
+		Position: cronerr.Position{Offset: token.Pos, Length: len(token.Value)},
+		Value:    token.Value,
+		Context:  context,
+		Expected: expected,
+	}
+}
+
+// unexpectedPart returns a syntax error for a whole part that is valid syntax,
+// but isn't allowed where it is
+func unexpectedPart(part Part, context, expected string) error {
+	return locate(&cronerr.SyntaxError{
+		Value:    part.String(),
+		Context:  context,
+		Expected: expected,
+	}, part[0], part[len(part)-1])
+}
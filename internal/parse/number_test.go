@@ -12,6 +12,7 @@ import (
 type stubNumbererProvider struct {
 	number func(numstr string) (numberer.Number, error)
 	rnge   func(startString, endString string) (numberer.Range, error)
+	from   func(startString string) (numberer.Range, error)
 	any    func() numberer.Any
 }
 
@@ -23,6 +24,10 @@ func (s stubNumbererProvider) Range(startString, endString string) (numberer.Ran
 	return s.rnge(startString, endString)
 }
 
+func (s stubNumbererProvider) From(startString string) (numberer.Range, error) {
+	return s.from(startString)
+}
+
 func (s stubNumbererProvider) Any() numberer.Any {
 	return s.any()
 }
@@ -53,6 +58,9 @@ func TestBaseNumbererFactory_NumbererNumber(t *testing.T) {
 func TestBaseNumbererFactory_NumbererRange(t *testing.T) {
 	timesCalled := 0
 	np := stubNumbererProvider{
+		number: func(numstr string) (numberer.Number, error) {
+			return 0, nil
+		},
 		rnge: func(startString, endString string) (n numberer.Range, err error) {
 			timesCalled++
 			return numberer.Range{
@@ -84,6 +92,40 @@ func TestBaseNumbererFactory_NumbererRange(t *testing.T) {
 	}
 }
 
+func TestBaseNumbererFactory_NumbererFrom(t *testing.T) {
+	timesCalled := 0
+	np := stubNumbererProvider{
+		from: func(startString string) (numberer.Range, error) {
+			timesCalled++
+			return numberer.Range{
+				Start: 5,
+				End:   59,
+			}, nil
+		},
+	}
+	tokens := parse.Part{
+		{
+			Type:  parse.TokenTypeNumber,
+			Value: "5",
+		},
+		{
+			Type:  parse.TokenTypeSlash,
+			Value: "/",
+		},
+		{
+			Type:  parse.TokenTypeNumber,
+			Value: "15",
+		},
+	}
+	_, err := parse.NewBaseNumbererFactory(np).Numberer(tokens)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if timesCalled != 1 {
+		t.Fatalf("expected timesCalled to be 1, got (%d)", timesCalled)
+	}
+}
+
 func TestBaseNumbererFactory_NumbererAny(t *testing.T) {
 	timesCalled := 0
 	np := stubNumbererProvider{
@@ -174,7 +216,7 @@ func TestStepNumbererFactory_NumbererSucceedsSteps(t *testing.T) {
 		},
 	}
 
-	stepNumberProvider := parse.NewStepNumbererFactory(snp)
+	stepNumberProvider := parse.NewStepNumbererFactory(snp, 60)
 	stepNumberer, err := stepNumberProvider.Numberer(tokens)
 	if err != nil {
 		t.Fatal(err)
@@ -216,7 +258,7 @@ func TestStepNumbererFactory_NumbererFailsInvalidStep(t *testing.T) {
 		},
 	}
 
-	stepNumberProvider := parse.NewStepNumbererFactory(snp)
+	stepNumberProvider := parse.NewStepNumbererFactory(snp, 60)
 	_, err := stepNumberProvider.Numberer(tokens)
 	if err == nil {
 		t.Fatal("expected an error, got none")
@@ -245,7 +287,7 @@ func TestStepNumbererFactory_NumbererNoStep(t *testing.T) {
 		},
 	}
 
-	stepNumberProvider := parse.NewStepNumbererFactory(snp)
+	stepNumberProvider := parse.NewStepNumbererFactory(snp, 60)
 	stepNumberer, err := stepNumberProvider.Numberer(tokens)
 	if err != nil {
 		t.Fatal(err)
@@ -287,9 +329,145 @@ func TestStepNumbererFactory_NumbererBaseFails(t *testing.T) {
 		},
 	}
 
-	stepNumberProvider := parse.NewStepNumbererFactory(snp)
+	stepNumberProvider := parse.NewStepNumbererFactory(snp, 60)
 	_, err := stepNumberProvider.Numberer(tokens)
 	if err == nil {
 		t.Fatal("expected an error, got none")
 	}
 }
+
+func TestParser_ParseSteps(t *testing.T) {
+	tests := []struct {
+		name            string
+		parser          parse.Parser
+		input           string
+		expectedNumbers []int
+	}{
+		{
+			name:            "second from a number",
+			parser:          parse.SecondParser,
+			input:           "10/20",
+			expectedNumbers: []int{10, 30, 50},
+		},
+		{
+			name:            "minute from a number",
+			parser:          parse.MinuteParser,
+			input:           "5/15",
+			expectedNumbers: []int{5, 20, 35, 50},
+		},
+		{
+			name:            "minute from the end",
+			parser:          parse.MinuteParser,
+			input:           "59/15",
+			expectedNumbers: []int{59},
+		},
+		{
+			name:            "minute range",
+			parser:          parse.MinuteParser,
+			input:           "5-30/15",
+			expectedNumbers: []int{5, 20},
+		},
+		{
+			name:            "minute any",
+			parser:          parse.MinuteParser,
+			input:           "*/20",
+			expectedNumbers: []int{0, 20, 40},
+		},
+		{
+			name:            "hour from a number",
+			parser:          parse.HourParser,
+			input:           "3/6",
+			expectedNumbers: []int{3, 9, 15, 21},
+		},
+		{
+			name:            "day of month from a number",
+			parser:          parse.DayOfMonthParser,
+			input:           "10/7",
+			expectedNumbers: []int{10, 17, 24, 31},
+		},
+		{
+			name:            "month from a number",
+			parser:          parse.MonthParser,
+			input:           "2/3",
+			expectedNumbers: []int{2, 5, 8, 11},
+		},
+		{
+			name:            "month from a name",
+			parser:          parse.MonthParser,
+			input:           "JUN/2",
+			expectedNumbers: []int{6, 8, 10, 12},
+		},
+		{
+			name:            "day of week from a number",
+			parser:          parse.DayOfWeekParser,
+			input:           "1/2",
+			expectedNumbers: []int{1, 3, 5},
+		},
+		{
+			name:            "day of week from a name",
+			parser:          parse.DayOfWeekParser,
+			input:           "TUE/2",
+			expectedNumbers: []int{2, 4, 6},
+		},
+		{
+			name:            "day of week from seven",
+			parser:          parse.DayOfWeekParser,
+			input:           "7/2",
+			expectedNumbers: []int{0},
+		},
+		{
+			name:            "quartz day of week from a number",
+			parser:          parse.QuartzDayOfWeekParser,
+			input:           "2/2",
+			expectedNumbers: []int{1, 3, 5},
+		},
+		{
+			name:            "quartz day of month from a number",
+			parser:          parse.QuartzDayOfMonthParser,
+			input:           "1/10",
+			expectedNumbers: []int{1, 11, 21, 31},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			num, err := test.parser.Parse(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotNumbers := num.Numbers(); !reflect.DeepEqual(test.expectedNumbers, gotNumbers) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expectedNumbers, gotNumbers)
+			}
+		})
+	}
+}
+
+func TestParser_ParseStepsFails(t *testing.T) {
+	tests := []struct {
+		name   string
+		parser parse.Parser
+		input  string
+	}{
+		{
+			name:   "minute out of range",
+			parser: parse.MinuteParser,
+			input:  "60/15",
+		},
+		{
+			name:   "month below range",
+			parser: parse.MonthParser,
+			input:  "0/2",
+		},
+		{
+			name:   "unknown name",
+			parser: parse.DayOfWeekParser,
+			input:  "FOO/2",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := test.parser.Parse(test.input); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
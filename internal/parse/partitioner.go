@@ -1,6 +1,11 @@
 package parse
 
-import "errors"
+import (
+	"errors"
+	"strings"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+)
 
 // Part is a group of tokens, that represent a single item, within a field of a
 // cron expression, e.g. (0-30/5) within an a field like "0-30/5,40,50"
@@ -15,11 +20,41 @@ func (p Part) Types() Types {
 	return types
 }
 
+// String implements fmt.Stringer and returns the part as it was written
+func (p Part) String() string {
+	var builder strings.Builder
+	for _, token := range p {
+		builder.WriteString(token.Value)
+	}
+	return builder.String()
+}
+
+// special returns the first Quartz special character in the part
+func (p Part) special() Token {
+	for _, token := range p {
+		if token.Type.IsSpecial() {
+			return token
+		}
+	}
+	return Token{}
+}
+
 // NewPartitioner is a type that can parse a field of a cron statement into it's
-// constituent parts
-func NewPartitioner() Partitioner {
+// constituent parts, for a field with the given syntax
+func NewPartitioner(syntax Syntax) Partitioner {
 	return Partitioner{
-		TokenSource: NewTokenSource,
+		Tokens: NewTokenIterator,
+		Syntax: syntax,
+	}
+}
+
+// NewAllErrorsPartitioner will return a Partitioner that carries on after an
+// error, so that it can return all of the errors in a field
+func NewAllErrorsPartitioner(syntax Syntax) Partitioner {
+	return Partitioner{
+		Tokens:    NewRecoveringTokenIterator,
+		Syntax:    syntax,
+		AllErrors: true,
 	}
 }
 
@@ -29,28 +64,57 @@ type PartsProvider interface {
 	Parts(input string) ([]Part, error)
 }
 
-// Partitioner is a type that adapts a Tokeniser into a list of Parts
+// Partitioner is a type that adapts a Tokeniser into a list of Parts, if
+// AllErrors is set it will carry on after an error, returning the parts that
+// didn't have errors along with cronerr.Errors. Syntax is the syntax of the
+// field, which is given to the Tokeniser for its errors.
 type Partitioner struct {
-	TokenSource func(input string) TokenSource
+	Tokens    func(input string, syntax Syntax) TokenIterator
+	Syntax    Syntax
+	AllErrors bool
 }
 
 // Parts will read all of the tokens from the Tokeniser and will split them based on the
-// commas in the input into their parts
+// commas in the input into their parts, the parts share a single slice of
+// tokens so that there is only one allocation for them
 func (p Partitioner) Parts(input string) ([]Part, error) {
-	tokens := p.TokenSource(input).Tokens()
-	parts := make([]Part, 0)
-	part := make(Part, 0)
-	for token := range tokens {
+	iterator := p.Tokens(input, p.Syntax)
+	// there are at most as many tokens as bytes in the input
+	tokens := make([]Token, 0, len(input))
+	parts := make([]Part, 0, strings.Count(input, ",")+1)
+	var errs cronerr.Errors
+	start, broken := 0, false
+	for token, ok := iterator.NextToken(); ok; token, ok = iterator.NextToken() {
 		if token.Type == TokenTypeError {
-			return nil, errors.New(token.Value)
+			if !p.AllErrors {
+				return nil, tokenError(token)
+			}
+			errs = append(errs, tokenError(token))
+			broken = true
+			continue
 		}
 		if token.Type == TokenTypeComma {
-			parts = append(parts, part)
-			part = make(Part, 0)
+			if !broken {
+				parts = append(parts, tokens[start:len(tokens):len(tokens)])
+			}
+			start, broken = len(tokens), false
 			continue
 		}
-		part = append(part, token)
+		tokens = append(tokens, token)
+	}
+	if !broken {
+		parts = append(parts, tokens[start:len(tokens):len(tokens)])
+	}
+	if len(errs) > 0 {
+		return parts, errs
 	}
-	parts = append(parts, part)
 	return parts, nil
 }
+
+// tokenError returns the error that an error token describes
+func tokenError(token Token) error {
+	if token.Err != nil {
+		return token.Err
+	}
+	return errors.New(token.Value)
+}
@@ -1,31 +1,34 @@
 package parse_test
 
 import (
+	"reflect"
 	"testing"
 
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 	"github.com/alistairjudson/cronparse/internal/parse"
 )
 
-type stubTokenSource struct {
-	tokens chan parse.Token
+type stubTokenIterator struct {
+	tokens []parse.Token
 }
 
-func (s stubTokenSource) Tokens() chan parse.Token {
-	return s.tokens
+func (s *stubTokenIterator) NextToken() (parse.Token, bool) {
+	if len(s.tokens) == 0 {
+		return parse.Token{}, false
+	}
+	token := s.tokens[0]
+	s.tokens = s.tokens[1:]
+	return token, true
 }
 
 func TestPartitioner_PartsFails(t *testing.T) {
-	sts := stubTokenSource{
-		tokens: make(chan parse.Token, 1),
-	}
-	sts.tokens <- parse.Token{
+	sti := &stubTokenIterator{tokens: []parse.Token{{
 		Type:  parse.TokenTypeError,
 		Value: "an error",
-	}
-	close(sts.tokens)
-	partitioner := parse.NewPartitioner()
-	partitioner.TokenSource = func(input string) parse.TokenSource {
-		return sts
+	}}}
+	partitioner := parse.NewPartitioner(parse.AnySyntax)
+	partitioner.Tokens = func(input string, syntax parse.Syntax) parse.TokenIterator {
+		return sti
 	}
 
 	_, err := partitioner.Parts("foo")
@@ -35,25 +38,23 @@ func TestPartitioner_PartsFails(t *testing.T) {
 }
 
 func TestPartitioner_PartsSucceds(t *testing.T) {
-	sts := stubTokenSource{
-		tokens: make(chan parse.Token, 3),
-	}
-	sts.tokens <- parse.Token{
-		Type:  parse.TokenTypeNumber,
-		Value: "1",
-	}
-	sts.tokens <- parse.Token{
-		Type:  parse.TokenTypeComma,
-		Value: ",",
-	}
-	sts.tokens <- parse.Token{
-		Type:  parse.TokenTypeNumber,
-		Value: "1",
-	}
-	close(sts.tokens)
-	partitioner := parse.NewPartitioner()
-	partitioner.TokenSource = func(input string) parse.TokenSource {
-		return sts
+	sti := &stubTokenIterator{tokens: []parse.Token{
+		{
+			Type:  parse.TokenTypeNumber,
+			Value: "1",
+		},
+		{
+			Type:  parse.TokenTypeComma,
+			Value: ",",
+		},
+		{
+			Type:  parse.TokenTypeNumber,
+			Value: "1",
+		},
+	}}
+	partitioner := parse.NewPartitioner(parse.AnySyntax)
+	partitioner.Tokens = func(input string, syntax parse.Syntax) parse.TokenIterator {
+		return sti
 	}
 
 	parts, err := partitioner.Parts("foo")
@@ -64,3 +65,19 @@ func TestPartitioner_PartsSucceds(t *testing.T) {
 		t.Fatalf("expected 2 parts, got (%d)", len(parts))
 	}
 }
+
+func TestAllErrorsPartitioner_Parts(t *testing.T) {
+	parts, err := parse.NewAllErrorsPartitioner(parse.AnySyntax).Parts("1,2a,3,*/,4")
+	errs, ok := err.(cronerr.Errors)
+	if !ok || len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got (%v)", err)
+	}
+	expected := []string{"1", "3", "4"}
+	got := make([]string, 0, len(parts))
+	for _, part := range parts {
+		got = append(got, part.String())
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
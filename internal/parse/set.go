@@ -0,0 +1,160 @@
+package parse
+
+import "math/bits"
+
+// wordSize is the number of values held by each word of a Set
+const wordSize = 64
+
+// NewSet will create a Set containing the given values, the values of each of
+// the time components fit in a single word, years use a word for every 64
+// years between the smallest and largest
+func NewSet(values ...int) Set {
+	if len(values) == 0 {
+		return Set{}
+	}
+	smallest, largest := values[0], values[0]
+	for _, value := range values {
+		if value < smallest {
+			smallest = value
+		}
+		if value > largest {
+			largest = value
+		}
+	}
+	base := smallest - mod(smallest, wordSize)
+	set := Set{base: base}
+	if words := (largest-base)/wordSize + 1; words > 1 {
+		set.rest = make([]uint64, words-1)
+	}
+	for _, value := range values {
+		set.add(value)
+	}
+	return set
+}
+
+// Set is a set of the values of a field, held as a uint64 bitmask so that
+// checking whether a value is in it takes constant time
+type Set struct {
+	// base is the value of the first bit of the first word, it is a multiple
+	// of wordSize
+	base int
+	// first is the first word, which holds all of the values of the time
+	// components without allocating, rest holds the words after it
+	first uint64
+	rest  []uint64
+}
+
+func (s *Set) add(value int) {
+	offset := value - s.base
+	if i := offset / wordSize; i > 0 {
+		s.rest[i-1] |= 1 << uint(offset%wordSize)
+		return
+	}
+	s.first |= 1 << uint(offset%wordSize)
+}
+
+// word returns the ith word of the set
+func (s Set) word(i int) uint64 {
+	if i == 0 {
+		return s.first
+	}
+	return s.rest[i-1]
+}
+
+// words returns the number of words in the set
+func (s Set) words() int {
+	return len(s.rest) + 1
+}
+
+// Contains tells you whether the value is in the set
+func (s Set) Contains(value int) bool {
+	offset := value - s.base
+	if offset < 0 || offset >= s.words()*wordSize {
+		return false
+	}
+	return s.word(offset/wordSize)&(1<<uint(offset%wordSize)) != 0
+}
+
+// NextSetBit returns the smallest value in the set that is greater than or
+// equal to value, it returns false if there isn't one
+func (s Set) NextSetBit(value int) (int, bool) {
+	offset := value - s.base
+	if offset < 0 {
+		offset = 0
+	}
+	for i := offset / wordSize; i < s.words(); i++ {
+		word := s.word(i)
+		if i == offset/wordSize {
+			// ignore the values before the offset in its word
+			word &= ^uint64(0) << uint(offset%wordSize)
+		}
+		if word != 0 {
+			return s.base + i*wordSize + bits.TrailingZeros64(word), true
+		}
+	}
+	return 0, false
+}
+
+// PrevSetBit returns the largest value in the set that is less than or equal
+// to value, it returns false if there isn't one
+func (s Set) PrevSetBit(value int) (int, bool) {
+	offset := value - s.base
+	if offset < 0 {
+		return 0, false
+	}
+	if last := s.words()*wordSize - 1; offset > last {
+		offset = last
+	}
+	for i := offset / wordSize; i >= 0; i-- {
+		word := s.word(i)
+		if i == offset/wordSize {
+			// ignore the values after the offset in its word
+			word &= ^uint64(0) >> uint(wordSize-1-offset%wordSize)
+		}
+		if word != 0 {
+			return s.base + i*wordSize + wordSize - 1 - bits.LeadingZeros64(word), true
+		}
+	}
+	return 0, false
+}
+
+// Len returns the number of values in the set
+func (s Set) Len() int {
+	length := 0
+	for i := 0; i < s.words(); i++ {
+		length += bits.OnesCount64(s.word(i))
+	}
+	return length
+}
+
+// Each will call fn with each of the values in the set, in ascending order
+func (s Set) Each(fn func(value int)) {
+	for i := 0; i < s.words(); i++ {
+		for word := s.word(i); word != 0; {
+			fn(s.base + i*wordSize + bits.TrailingZeros64(word))
+			// clear the lowest set bit
+			word &= word - 1
+		}
+	}
+}
+
+// Numbers implements Numberer and returns the values in the set in ascending
+// order
+func (s Set) Numbers() []int {
+	return s.Values()
+}
+
+// Values returns the values in the set in ascending order
+func (s Set) Values() []int {
+	values := make([]int, 0, s.Len())
+	s.Each(func(value int) {
+		values = append(values, value)
+	})
+	return values
+}
+
+// mod returns the remainder of value divided by n, which is positive even if
+// value is negative
+func mod(value, n int) int {
+	return ((value % n) + n) % n
+}
@@ -0,0 +1,134 @@
+package parse_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alistairjudson/cronparse/internal/numberer"
+	"github.com/alistairjudson/cronparse/internal/parse"
+)
+
+func TestNewSet_Values(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   []int
+		expected []int
+	}{
+		{
+			name:     "empty",
+			values:   nil,
+			expected: []int{},
+		},
+		{
+			name:     "sorts and removes duplicates",
+			values:   []int{30, 0, 59, 30, 15},
+			expected: []int{0, 15, 30, 59},
+		},
+		{
+			name:     "years across words",
+			values:   []int{2099, 1970, 2024, 2048},
+			expected: []int{1970, 2024, 2048, 2099},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			set := parse.NewSet(test.values...)
+			if got := set.Values(); !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+			if got := set.Len(); got != len(test.expected) {
+				t.Fatalf("expected (%d), got (%d)", len(test.expected), got)
+			}
+		})
+	}
+}
+
+func TestSet_Contains(t *testing.T) {
+	set := parse.NewSet(0, 17, 63, 1970, 2099)
+	tests := []struct {
+		value    int
+		expected bool
+	}{
+		{value: 0, expected: true},
+		{value: 17, expected: true},
+		{value: 18, expected: false},
+		{value: 63, expected: true},
+		{value: 64, expected: false},
+		{value: -1, expected: false},
+		{value: 1970, expected: true},
+		{value: 2024, expected: false},
+		{value: 2099, expected: true},
+		{value: 2100, expected: false},
+	}
+	for _, test := range tests {
+		if got := set.Contains(test.value); got != test.expected {
+			t.Fatalf("expected (%d) to be (%t), got (%t)", test.value, test.expected, got)
+		}
+	}
+	if (parse.Set{}).Contains(0) {
+		t.Fatal("expected the empty set not to contain (0)")
+	}
+}
+
+func TestSet_NextSetBit(t *testing.T) {
+	set := parse.NewSet(5, 30, 1999, 2030)
+	tests := []struct {
+		name     string
+		value    int
+		expected int
+		ok       bool
+	}{
+		{name: "before the set", value: -10, expected: 5, ok: true},
+		{name: "in the set", value: 30, expected: 30, ok: true},
+		{name: "between values", value: 6, expected: 30, ok: true},
+		{name: "across words", value: 31, expected: 1999, ok: true},
+		{name: "in a later word", value: 2000, expected: 2030, ok: true},
+		{name: "after the set", value: 2031, ok: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := set.NextSetBit(test.value)
+			if ok != test.ok || got != test.expected {
+				t.Fatalf("expected (%d, %t), got (%d, %t)", test.expected, test.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestSet_PrevSetBit(t *testing.T) {
+	set := parse.NewSet(5, 30, 1999, 2030)
+	tests := []struct {
+		name     string
+		value    int
+		expected int
+		ok       bool
+	}{
+		{name: "before the set", value: 4, ok: false},
+		{name: "in the set", value: 30, expected: 30, ok: true},
+		{name: "between values", value: 29, expected: 5, ok: true},
+		{name: "across words", value: 1998, expected: 30, ok: true},
+		{name: "in a later word", value: 2029, expected: 1999, ok: true},
+		{name: "after the set", value: 5000, expected: 2030, ok: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := set.PrevSetBit(test.value)
+			if ok != test.ok || got != test.expected {
+				t.Fatalf("expected (%d, %t), got (%d, %t)", test.expected, test.ok, got, ok)
+			}
+		})
+	}
+}
+
+func TestAggregateNumberer_Set(t *testing.T) {
+	aggregate := parse.AggregateNumberer{
+		numberer.Any{22, 23},
+		numberer.Number(0),
+		numberer.Number(2),
+		numberer.Number(22),
+	}
+	expected := []int{0, 2, 22, 23}
+	if got := aggregate.Set().Values(); !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
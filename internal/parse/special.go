@@ -0,0 +1,308 @@
+package parse
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+	"github.com/alistairjudson/cronparse/internal/numberer"
+)
+
+var (
+	// QuartzDayOfMonthParser is a parser to parse the day of month component of
+	// a Quartz cron expression, which can contain the special characters (L W ?)
+	QuartzDayOfMonthParser = NewQuartzDayOfMonthParser(numberer.DayOfMonthFactory)
+
+	// QuartzDayOfWeekParser is a parser to parse the day of week component of
+	// a Quartz cron expression, which can contain the special characters (L # ?)
+	// and numbers the days from 1-7 (SUN-SAT)
+	QuartzDayOfWeekParser = NewQuartzDayOfWeekParser(numberer.QuartzDayOfWeekFactory)
+)
+
+// NewQuartzDayOfMonthParser will return you a new parser for the day of month
+// component of a Quartz cron expression, for a given numberer.Provider
+func NewQuartzDayOfMonthParser(provider numberer.Provider) Parser {
+	return NewAdapter(
+		NewStepNumbererFactory(
+			NewDayOfMonthSpecialFactory(provider),
+			len(provider.Any()),
+		),
+		SyntaxOf(provider).withDayOfMonthSpecials(),
+	)
+}
+
+// NewQuartzDayOfWeekParser will return you a new parser for the day of week
+// component of a Quartz cron expression, for a given numberer.Provider
+func NewQuartzDayOfWeekParser(provider numberer.Provider) Parser {
+	return NewAdapter(
+		NewStepNumbererFactory(
+			NewDayOfWeekSpecialFactory(provider),
+			len(provider.Any()),
+		),
+		SyntaxOf(provider).withDayOfWeekSpecials(),
+	)
+}
+
+const (
+	daysInWeek     = 7
+	maxWeekInMonth = 5
+	maxLastOffset  = 30
+)
+
+// DateMatcher is a Numberer whose values depend on the date, such as the last
+// day of the month, so they can't be expanded into numbers ahead of time
+type DateMatcher interface {
+	Numberer
+	fmt.Stringer
+	MatchesDate(year int, month time.Month, day int) bool
+}
+
+// NewDayOfMonthSpecialFactory will create a new instance of the
+// DayOfMonthSpecialFactory, for a numberer.Provider
+func NewDayOfMonthSpecialFactory(factory numberer.Provider) DayOfMonthSpecialFactory {
+	return DayOfMonthSpecialFactory{
+		Base:    NewBaseNumbererFactory(factory),
+		Factory: factory,
+	}
+}
+
+// DayOfMonthSpecialFactory is a decorator of a Provider, that handles the
+// special characters that are allowed in the day of month component
+type DayOfMonthSpecialFactory struct {
+	Base    NumbererProvider
+	Factory numberer.Provider
+}
+
+// Numberer implements Provider and will return a DateMatcher for parts that
+// contain (L or W), Any for (?), otherwise it will return the base
+func (d DayOfMonthSpecialFactory) Numberer(part Part) (Numberer, error) {
+	types := part.Types()
+	switch {
+	case types.Equals(TokenTypeNoSpecific):
+		return d.Factory.Any(), nil
+	case types.Equals(TokenTypeLast):
+		return LastDayOfMonth{}, nil
+	case types.Equals(TokenTypeLast, TokenTypeDash, TokenTypeNumber):
+		offset, err := strconv.Atoi(part[2].Value)
+		if err != nil || offset > maxLastOffset {
+			return nil, locate(&cronerr.RangeError{
+				What:  "offset from the last day",
+				Value: part[2].Value,
+				Max:   maxLastOffset,
+			}, part[2], part[2])
+		}
+		return LastDayOfMonth{Offset: offset}, nil
+	case types.Equals(TokenTypeLast, TokenTypeWeekday):
+		return LastWeekdayOfMonth{}, nil
+	case types.Equals(TokenTypeNumber, TokenTypeWeekday):
+		day, err := d.Factory.Number(part[0].Value)
+		if err != nil {
+			return nil, locate(err, part[0], part[0])
+		}
+		return NearestWeekday{Day: int(day)}, nil
+	case types.Contains(TokenTypeLast) || types.Contains(TokenTypeWeekday):
+		return nil, unexpectedPart(part, "in the day of month", "(L), (L-n), (LW) or (nW)")
+	}
+	return d.Base.Numberer(part)
+}
+
+// NewDayOfWeekSpecialFactory will create a new instance of the
+// DayOfWeekSpecialFactory, for a numberer.Provider
+func NewDayOfWeekSpecialFactory(factory numberer.Provider) DayOfWeekSpecialFactory {
+	return DayOfWeekSpecialFactory{
+		Base:    NewBaseNumbererFactory(factory),
+		Factory: factory,
+	}
+}
+
+// DayOfWeekSpecialFactory is a decorator of a Provider, that handles the
+// special characters that are allowed in the day of week component
+type DayOfWeekSpecialFactory struct {
+	Base    NumbererProvider
+	Factory numberer.Provider
+}
+
+// Numberer implements Provider and will return a DateMatcher for parts that
+// contain (L or #), saturday for (L) on its own, Any for (?), otherwise it
+// will return the base
+func (d DayOfWeekSpecialFactory) Numberer(part Part) (Numberer, error) {
+	types := part.Types()
+	switch {
+	case types.Equals(TokenTypeNoSpecific):
+		return d.Factory.Any(), nil
+	case types.Equals(TokenTypeLast):
+		// on its own (L) is the last day of the week
+		return numberer.Number(time.Saturday), nil
+	case len(types) == 2 && types.StartsWith(TokenTypeNumber, TokenTypeName) && types[1] == TokenTypeLast:
+		weekday, err := d.Factory.Number(part[0].Value)
+		if err != nil {
+			return nil, locate(err, part[0], part[0])
+		}
+		return LastWeekday{Weekday: time.Weekday(weekday)}, nil
+	case len(types) == 3 && types.StartsWith(TokenTypeNumber, TokenTypeName) &&
+		types[1] == TokenTypeHash && types[2] == TokenTypeNumber:
+		weekday, err := d.Factory.Number(part[0].Value)
+		if err != nil {
+			return nil, locate(err, part[0], part[0])
+		}
+		week, err := strconv.Atoi(part[2].Value)
+		if err != nil || week < 1 || week > maxWeekInMonth {
+			return nil, locate(&cronerr.RangeError{
+				What:  "week",
+				Value: part[2].Value,
+				Min:   1,
+				Max:   maxWeekInMonth,
+			}, part[2], part[2])
+		}
+		return NthWeekday{Weekday: time.Weekday(weekday), Week: week}, nil
+	case types.Contains(TokenTypeLast) || types.Contains(TokenTypeHash):
+		return nil, unexpectedPart(part, "in the day of week", "a day followed by (L) or (#n)")
+	case types.Contains(TokenTypeWeekday):
+		return nil, unexpected(part.special(), "in the day of week", "a day followed by (L) or (#n)")
+	}
+	return d.Base.Numberer(part)
+}
+
+// LastDayOfMonth is a DateMatcher that matches the last day of the month, or
+// a number of days before it
+type LastDayOfMonth struct {
+	Offset int
+}
+
+// Numbers implements Numberer, it has no numbers as they depend on the month
+func (l LastDayOfMonth) Numbers() []int {
+	return nil
+}
+
+// MatchesDate implements DateMatcher and tells you whether the day is the
+// last day of the month, minus the offset
+func (l LastDayOfMonth) MatchesDate(year int, month time.Month, day int) bool {
+	return day == daysIn(year, month)-l.Offset
+}
+
+// String implements fmt.Stringer and returns the Quartz syntax for the matcher
+func (l LastDayOfMonth) String() string {
+	if l.Offset == 0 {
+		return "L"
+	}
+	return fmt.Sprintf("L-%d", l.Offset)
+}
+
+// LastWeekdayOfMonth is a DateMatcher that matches the last weekday (MON-FRI)
+// of the month
+type LastWeekdayOfMonth struct{}
+
+// Numbers implements Numberer, it has no numbers as they depend on the month
+func (l LastWeekdayOfMonth) Numbers() []int {
+	return nil
+}
+
+// MatchesDate implements DateMatcher and tells you whether the day is the
+// last weekday of the month
+func (l LastWeekdayOfMonth) MatchesDate(year int, month time.Month, day int) bool {
+	return NearestWeekday{Day: daysIn(year, month)}.MatchesDate(year, month, day)
+}
+
+// String implements fmt.Stringer and returns the Quartz syntax for the matcher
+func (l LastWeekdayOfMonth) String() string {
+	return "LW"
+}
+
+// NearestWeekday is a DateMatcher that matches the weekday (MON-FRI) nearest
+// to a day of the month, without moving into another month
+type NearestWeekday struct {
+	Day int
+}
+
+// Numbers implements Numberer, it has no numbers as they depend on the month
+func (n NearestWeekday) Numbers() []int {
+	return nil
+}
+
+// MatchesDate implements DateMatcher and tells you whether the day is the
+// weekday nearest to the day of the matcher
+func (n NearestWeekday) MatchesDate(year int, month time.Month, day int) bool {
+	last := daysIn(year, month)
+	if n.Day > last {
+		return false
+	}
+	nearest := n.Day
+	switch time.Date(year, month, n.Day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		nearest--
+		if nearest < 1 {
+			nearest += 3
+		}
+	case time.Sunday:
+		nearest++
+		if nearest > last {
+			nearest -= 3
+		}
+	}
+	return day == nearest
+}
+
+// String implements fmt.Stringer and returns the Quartz syntax for the matcher
+func (n NearestWeekday) String() string {
+	return fmt.Sprintf("%dW", n.Day)
+}
+
+// LastWeekday is a DateMatcher that matches the last of a day of the week in
+// the month, e.g. the last friday
+type LastWeekday struct {
+	Weekday time.Weekday
+}
+
+// Numbers implements Numberer, it has no numbers as they depend on the month
+func (l LastWeekday) Numbers() []int {
+	return nil
+}
+
+// MatchesDate implements DateMatcher and tells you whether the day is the
+// last of the day of the week in the month
+func (l LastWeekday) MatchesDate(year int, month time.Month, day int) bool {
+	weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	return weekday == l.Weekday && day+daysInWeek > daysIn(year, month)
+}
+
+// String implements fmt.Stringer and returns the Quartz syntax for the matcher,
+// with the name of the day so that it is the same in either numbering
+func (l LastWeekday) String() string {
+	return weekdayName(l.Weekday) + "L"
+}
+
+// NthWeekday is a DateMatcher that matches a week's day of the week within the
+// month, e.g. the third thursday
+type NthWeekday struct {
+	Weekday time.Weekday
+	Week    int
+}
+
+// Numbers implements Numberer, it has no numbers as they depend on the month
+func (n NthWeekday) Numbers() []int {
+	return nil
+}
+
+// MatchesDate implements DateMatcher and tells you whether the day is the
+// day of the week, in the week of the month
+func (n NthWeekday) MatchesDate(year int, month time.Month, day int) bool {
+	weekday := time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday()
+	return weekday == n.Weekday && (day-1)/daysInWeek+1 == n.Week
+}
+
+// String implements fmt.Stringer and returns the Quartz syntax for the matcher,
+// with the name of the day so that it is the same in either numbering
+func (n NthWeekday) String() string {
+	return fmt.Sprintf("%s#%d", weekdayName(n.Weekday), n.Week)
+}
+
+func weekdayName(weekday time.Weekday) string {
+	return strings.ToUpper(weekday.String()[:3])
+}
+
+// daysIn returns the number of days in a month, taking leap years into account
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
@@ -0,0 +1,262 @@
+package parse_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse/internal/parse"
+)
+
+func daysOfMonth() []int {
+	days := make([]int, 0, 31)
+	for day := 1; day <= 31; day++ {
+		days = append(days, day)
+	}
+	return days
+}
+
+func TestQuartzDayOfMonthParser_ParseSucceeds(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		expectedNumbers  []int
+		expectedMatchers []parse.DateMatcher
+	}{
+		{
+			name:            "number",
+			input:           "15",
+			expectedNumbers: []int{15},
+		},
+		{
+			name:            "no specific",
+			input:           "?",
+			expectedNumbers: daysOfMonth(),
+		},
+		{
+			name:             "last",
+			input:            "L",
+			expectedNumbers:  []int{},
+			expectedMatchers: []parse.DateMatcher{parse.LastDayOfMonth{}},
+		},
+		{
+			name:             "last with offset",
+			input:            "L-3",
+			expectedNumbers:  []int{},
+			expectedMatchers: []parse.DateMatcher{parse.LastDayOfMonth{Offset: 3}},
+		},
+		{
+			name:             "last weekday",
+			input:            "LW",
+			expectedNumbers:  []int{},
+			expectedMatchers: []parse.DateMatcher{parse.LastWeekdayOfMonth{}},
+		},
+		{
+			name:             "nearest weekday and number",
+			input:            "15W,1",
+			expectedNumbers:  []int{1},
+			expectedMatchers: []parse.DateMatcher{parse.NearestWeekday{Day: 15}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			numberer, err := parse.QuartzDayOfMonthParser.Parse(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := numberer.Numbers(); !reflect.DeepEqual(test.expectedNumbers, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expectedNumbers, got)
+			}
+			got := numberer.(parse.AggregateNumberer).DateMatchers()
+			if !reflect.DeepEqual(test.expectedMatchers, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expectedMatchers, got)
+			}
+		})
+	}
+}
+
+func TestQuartzDayOfMonthParser_ParseFails(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "offset too large", input: "L-31"},
+		{name: "nearest weekday out of range", input: "32W"},
+		{name: "hash", input: "1#2"},
+		{name: "last after number", input: "5L"},
+		{name: "last in range", input: "1-L"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parse.QuartzDayOfMonthParser.Parse(test.input); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestQuartzDayOfWeekParser_ParseSucceeds(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		expectedNumbers  []int
+		expectedMatchers []parse.DateMatcher
+	}{
+		{
+			name:            "numbers are sunday to saturday",
+			input:           "1,7",
+			expectedNumbers: []int{0, 6},
+		},
+		{
+			name:            "no specific",
+			input:           "?",
+			expectedNumbers: []int{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:            "last on its own is saturday",
+			input:           "L",
+			expectedNumbers: []int{6},
+		},
+		{
+			name:             "last friday",
+			input:            "6L",
+			expectedNumbers:  []int{},
+			expectedMatchers: []parse.DateMatcher{parse.LastWeekday{Weekday: time.Friday}},
+		},
+		{
+			name:             "last friday by name",
+			input:            "fril",
+			expectedNumbers:  []int{},
+			expectedMatchers: []parse.DateMatcher{parse.LastWeekday{Weekday: time.Friday}},
+		},
+		{
+			name:             "third thursday",
+			input:            "THU#3",
+			expectedNumbers:  []int{},
+			expectedMatchers: []parse.DateMatcher{parse.NthWeekday{Weekday: time.Thursday, Week: 3}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			numberer, err := parse.QuartzDayOfWeekParser.Parse(test.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := numberer.Numbers(); !reflect.DeepEqual(test.expectedNumbers, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expectedNumbers, got)
+			}
+			got := numberer.(parse.AggregateNumberer).DateMatchers()
+			if !reflect.DeepEqual(test.expectedMatchers, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expectedMatchers, got)
+			}
+		})
+	}
+}
+
+func TestQuartzDayOfWeekParser_ParseFails(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "weekday", input: "5W"},
+		{name: "week too large", input: "5#6"},
+		{name: "week zero", input: "5#0"},
+		{name: "day out of range", input: "8L"},
+		{name: "unknown name before last", input: "FRIDAYL"},
+		{name: "last after last", input: "LL"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := parse.QuartzDayOfWeekParser.Parse(test.input); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestDayOfMonthParser_ParseFailsSpecial(t *testing.T) {
+	for _, input := range []string{"L", "15W", "?", "5L"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := parse.DayOfMonthParser.Parse(input); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestDateMatcher_MatchesDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		matcher  parse.DateMatcher
+		date     string
+		expected bool
+	}{
+		{name: "last day", matcher: parse.LastDayOfMonth{}, date: "2020-02-29", expected: true},
+		{name: "not last day", matcher: parse.LastDayOfMonth{}, date: "2021-02-27", expected: false},
+		{name: "last day with offset", matcher: parse.LastDayOfMonth{Offset: 2}, date: "2021-01-29", expected: true},
+		{name: "last weekday", matcher: parse.LastWeekdayOfMonth{}, date: "2021-01-29", expected: true},
+		{name: "last weekday is not a saturday", matcher: parse.LastWeekdayOfMonth{}, date: "2021-07-31", expected: false},
+		{name: "nearest weekday of saturday", matcher: parse.NearestWeekday{Day: 15}, date: "2021-05-14", expected: true},
+		{name: "nearest weekday of sunday", matcher: parse.NearestWeekday{Day: 16}, date: "2021-05-17", expected: true},
+		{name: "nearest weekday stays in the month", matcher: parse.NearestWeekday{Day: 1}, date: "2021-05-03", expected: true},
+		{name: "nearest weekday at the end of the month", matcher: parse.NearestWeekday{Day: 31}, date: "2021-10-29", expected: true},
+		{name: "nearest weekday of a missing day", matcher: parse.NearestWeekday{Day: 31}, date: "2021-04-30", expected: false},
+		{name: "last friday", matcher: parse.LastWeekday{Weekday: time.Friday}, date: "2021-04-30", expected: true},
+		{name: "not last friday", matcher: parse.LastWeekday{Weekday: time.Friday}, date: "2021-04-23", expected: false},
+		{name: "third thursday", matcher: parse.NthWeekday{Weekday: time.Thursday, Week: 3}, date: "2021-04-15", expected: true},
+		{name: "not third thursday", matcher: parse.NthWeekday{Weekday: time.Thursday, Week: 3}, date: "2021-04-22", expected: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			date, err := time.Parse("2006-01-02", test.date)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := test.matcher.MatchesDate(date.Year(), date.Month(), date.Day())
+			if got != test.expected {
+				t.Fatalf("expected (%t), got (%t)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestDateMatcher_String(t *testing.T) {
+	tests := []struct {
+		matcher  parse.DateMatcher
+		expected string
+	}{
+		{matcher: parse.LastDayOfMonth{}, expected: "L"},
+		{matcher: parse.LastDayOfMonth{Offset: 3}, expected: "L-3"},
+		{matcher: parse.LastWeekdayOfMonth{}, expected: "LW"},
+		{matcher: parse.NearestWeekday{Day: 15}, expected: "15W"},
+		{matcher: parse.LastWeekday{Weekday: time.Friday}, expected: "FRIL"},
+		{matcher: parse.NthWeekday{Weekday: time.Thursday, Week: 3}, expected: "THU#3"},
+	}
+	for _, test := range tests {
+		t.Run(test.expected, func(t *testing.T) {
+			if got := test.matcher.String(); got != test.expected {
+				t.Fatalf("expected (%s), got (%s)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestDateMatcher_StringParses(t *testing.T) {
+	for _, matcher := range []parse.DateMatcher{
+		parse.LastWeekday{Weekday: time.Sunday},
+		parse.LastWeekday{Weekday: time.Friday},
+		parse.NthWeekday{Weekday: time.Thursday, Week: 3},
+		parse.NthWeekday{Weekday: time.Saturday, Week: 5},
+	} {
+		t.Run(matcher.String(), func(t *testing.T) {
+			numberer, err := parse.QuartzDayOfWeekParser.Parse(matcher.String())
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := []parse.DateMatcher{matcher}
+			if got := numberer.(parse.AggregateNumberer).DateMatchers(); !reflect.DeepEqual(expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", expected, got)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package parse
 
-import "unicode"
+import (
+	"strings"
+	"unicode"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+)
 
 // This files represents the all of the states that there can possibly be
 // within a field of a cron expression. This is a state machine, it does not
@@ -12,12 +17,30 @@ func lexField(t *Tokeniser) StateFunc {
 	switch {
 	case curr == '*':
 		return lexAny
+	case curr == '?':
+		return lexNoSpecific
 	case unicode.IsDigit(curr):
 		return lexNumber
-	case curr == eof:
-		return t.Errorf("input cannot be empty")
+	case unicode.IsLetter(curr):
+		return lexName
+	}
+	context := "at the start of the field"
+	if t.Start > 0 {
+		context = "after (,)"
+	}
+	return t.Unexpected(context, t.Syntax.start())
+}
+
+// lexSkip skips the rest of a part after an error, up to the next comma, so
+// that the parts after it can still be tokenised
+func lexSkip(t *Tokeniser) StateFunc {
+	for r := t.Next(); r != eof; r = t.Next() {
+		if r == ',' {
+			t.Start = t.Pos - t.Width
+			return lexComma
+		}
 	}
-	return t.Errorf(`(%c) is unexpected at the start of a statement, expected (* or [0-9]+)`, curr)
+	return nil
 }
 
 func lexComma(t *Tokeniser) StateFunc {
@@ -36,14 +59,34 @@ func lexAny(t *Tokeniser) StateFunc {
 	case eof:
 		return nil
 	}
-	return t.Errorf(`(%c) is unexpected after (*), only (/,) expected`, next)
+	return t.Unexpected("after (*)", t.Syntax.afterWildcard())
+}
+
+func lexNoSpecific(t *Tokeniser) StateFunc {
+	t.Emit(TokenTypeNoSpecific)
+	next := t.Next()
+	switch next {
+	case ',':
+		return lexComma
+	case eof:
+		return nil
+	}
+	return t.Unexpected("after (?)", "(,)")
 }
 
 func lexStep(t *Tokeniser) StateFunc {
 	t.Emit(TokenTypeSlash)
 	next := t.Next()
+	if next == eof || next == ',' {
+		// the step is missing, so the (/) may not be wanted at all
+		return t.Unexpected("after (/)", "a number", cronerr.Suggestion{
+			Offset:  t.Start - 1,
+			Length:  1,
+			Message: "remove the (/), or add a step after it",
+		})
+	}
 	if !unicode.IsDigit(next) {
-		return t.Errorf("(%c) is unexpected after a step, only numbers are expected", next)
+		return t.Unexpected("after (/)", "a number")
 	}
 	t.AcceptNumber()
 	t.Emit(TokenTypeNumber)
@@ -54,7 +97,7 @@ func lexStep(t *Tokeniser) StateFunc {
 	case eof:
 		return nil
 	}
-	return t.Errorf("(%c) is unexpected after a step, only (,) is expected", next)
+	return t.Unexpected("after a step", "(,)")
 }
 
 func lexNumber(t *Tokeniser) StateFunc {
@@ -68,20 +111,109 @@ func lexNumber(t *Tokeniser) StateFunc {
 		return lexRange
 	case '/':
 		return lexStep
+	case '#':
+		return lexHash
+	case 'L', 'l':
+		t.Emit(TokenTypeLast)
+		return lexSpecialEnd
+	case 'W', 'w':
+		t.Emit(TokenTypeWeekday)
+		return lexSpecialEnd
 	case eof:
 		return nil
 	}
-	return t.Errorf("(%c) is unexpected after a number, only (,-/) expected", next)
+	return t.Unexpected("after a number", t.Syntax.afterNumber())
 }
 
-func lexRange(t *Tokeniser) StateFunc {
-	t.Emit(TokenTypeDash)
+func lexName(t *Tokeniser) StateFunc {
+	t.AcceptName()
+	switch strings.ToUpper(t.Input[t.Start:t.Pos]) {
+	case "L":
+		t.Emit(TokenTypeLast)
+		return lexLast
+	case "W":
+		t.Emit(TokenTypeWeekday)
+		return lexSpecialEnd
+	case "LW":
+		t.Pos = t.Start + 1
+		t.Emit(TokenTypeLast)
+		t.Pos++
+		t.Emit(TokenTypeWeekday)
+		return lexSpecialEnd
+	}
+	if word := t.Input[t.Start:t.Pos]; t.Syntax.NameLast && strings.EqualFold(word[len(word)-1:], "L") {
+		// a name followed by (L), such as (FRIL)
+		t.Pos--
+		t.Emit(TokenTypeName)
+		t.Pos++
+		t.Emit(TokenTypeLast)
+		return lexSpecialEnd
+	}
+	t.Emit(TokenTypeName)
+	next := t.Next()
+	switch next {
+	case ',':
+		return lexComma
+	case '-':
+		return lexRange
+	case '/':
+		return lexStep
+	case '#':
+		return lexHash
+	case eof:
+		return nil
+	}
+	return t.Unexpected("after a name", t.Syntax.afterName())
+}
+
+func lexLast(t *Tokeniser) StateFunc {
+	next := t.Next()
+	switch next {
+	case ',':
+		return lexComma
+	case '-':
+		return lexRange
+	case eof:
+		return nil
+	}
+	return t.Unexpected("after (L)", t.Syntax.afterLast())
+}
+
+func lexHash(t *Tokeniser) StateFunc {
+	t.Emit(TokenTypeHash)
 	next := t.Next()
 	if !unicode.IsDigit(next) {
-		return t.Errorf("(%c) is unexpected in a range, only digits are expected", next)
+		return t.Unexpected("after (#)", "a number")
 	}
 	t.AcceptNumber()
 	t.Emit(TokenTypeNumber)
+	return lexSpecialEnd
+}
+
+func lexSpecialEnd(t *Tokeniser) StateFunc {
+	next := t.Next()
+	switch next {
+	case ',':
+		return lexComma
+	case eof:
+		return nil
+	}
+	return t.Unexpected("after a special character", "(,)")
+}
+
+func lexRange(t *Tokeniser) StateFunc {
+	t.Emit(TokenTypeDash)
+	next := t.Next()
+	switch {
+	case unicode.IsDigit(next):
+		t.AcceptNumber()
+		t.Emit(TokenTypeNumber)
+	case unicode.IsLetter(next):
+		t.AcceptName()
+		t.Emit(TokenTypeName)
+	default:
+		return t.Unexpected("after (-)", t.Syntax.value())
+	}
 	next = t.Next()
 	switch next {
 	case '/':
@@ -91,5 +223,5 @@ func lexRange(t *Tokeniser) StateFunc {
 	case eof:
 		return nil
 	}
-	return t.Errorf(`(%c) is unexpected after a range, only (/,) expected`, next)
+	return t.Unexpected("after a range", t.Syntax.afterWildcard())
 }
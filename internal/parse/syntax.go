@@ -0,0 +1,138 @@
+package parse
+
+import (
+	"strings"
+
+	"github.com/alistairjudson/cronparse/internal/numberer"
+)
+
+// Syntax is a type that describes the syntax that a field accepts, beyond
+// numbers, ranges and (*), so that the errors for input that doesn't fit it
+// only say what the field expects
+type Syntax struct {
+	// Names is set when the field accepts names, such as (JAN) or (MON)
+	Names bool
+	// Steps is set when the field accepts steps, such as (*/15)
+	Steps bool
+	// NoSpecific is set when the field accepts the Quartz (?)
+	NoSpecific bool
+	// Last is set when the field accepts (L) on its own, and LastOffset when
+	// it can be followed by an offset, such as (L-3)
+	Last, LastOffset bool
+	// DayLast is set when the field accepts (L) after a day, such as (6L),
+	// and NameLast when it also accepts it straight after a name, such as
+	// (FRIL), which makes a name that ends in (L) the name before it
+	DayLast, NameLast bool
+	// Weekday is set when the field accepts (W) after a day, such as (15W)
+	Weekday bool
+	// Hash is set when the field accepts (#) after a day, such as (5#3)
+	Hash bool
+}
+
+// AnySyntax is the syntax of all of the fields together, for tokenising a
+// field without knowing which field it is, apart from NameLast, so that the
+// names that end in (L), such as (JUL), are still names
+var AnySyntax = Syntax{
+	Names:      true,
+	Steps:      true,
+	NoSpecific: true,
+	Last:       true,
+	LastOffset: true,
+	DayLast:    true,
+	Weekday:    true,
+	Hash:       true,
+}
+
+// SyntaxOf returns the syntax of a field with the values of a provider, which
+// accepts steps, and names if the provider has them
+func SyntaxOf(provider numberer.Provider) Syntax {
+	named, ok := provider.(interface{ HasNames() bool })
+	return Syntax{Names: ok && named.HasNames(), Steps: true}
+}
+
+// withDayOfMonthSpecials returns the syntax with the Quartz special characters
+// of the day of month (? L L-n LW nW)
+func (s Syntax) withDayOfMonthSpecials() Syntax {
+	s.NoSpecific, s.Last, s.LastOffset, s.Weekday = true, true, true, true
+	return s
+}
+
+// withDayOfWeekSpecials returns the syntax with the Quartz special characters
+// of the day of week (? L nL n#m), and (L) after a name if it has names
+func (s Syntax) withDayOfWeekSpecials() Syntax {
+	s.NoSpecific, s.Last, s.DayLast, s.Hash = true, true, true, true
+	s.NameLast = s.Names
+	return s
+}
+
+// Allowed describes the values that a field with the syntax allows, given the
+// descriptions of its numbers and names, e.g. a number (0-59) or (*)
+func (s Syntax) Allowed(values []string) string {
+	return list(append(append([]string(nil), values...),
+		"(*)",
+		s.is(s.NoSpecific, "(?)"),
+		s.is(s.Last, "(L)"),
+		s.is(s.LastOffset, "(L-n)"),
+		s.is(s.Last && s.Weekday, "(LW)"),
+		s.is(s.Weekday, "(nW)"),
+		s.is(s.DayLast, "(nL)"),
+		s.is(s.Hash, "(n#m)"),
+	)...)
+}
+
+// start describes what can start an item
+func (s Syntax) start() string {
+	return list("(*)", s.is(s.NoSpecific, "(?)"), "a number", s.is(s.Names, "a name"), s.is(s.Last && !s.Names, "(L)"))
+}
+
+// afterNumber describes what can follow a number
+func (s Syntax) afterNumber() string {
+	return list("(,)", "(-)", s.is(s.Steps, "(/)"), s.is(s.Hash, "(#)"), s.is(s.DayLast, "(L)"), s.is(s.Weekday, "(W)"))
+}
+
+// afterName describes what can follow a name
+func (s Syntax) afterName() string {
+	return list("(,)", "(-)", s.is(s.Steps, "(/)"), s.is(s.Hash, "(#)"))
+}
+
+// afterLast describes what can follow (L) on its own
+func (s Syntax) afterLast() string {
+	return list("(,)", s.is(s.LastOffset, "(-)"))
+}
+
+// afterWildcard describes what can follow (*), or a range
+func (s Syntax) afterWildcard() string {
+	return list(s.is(s.Steps, "(/)"), "(,)")
+}
+
+// value describes a single value, such as the end of a range
+func (s Syntax) value() string {
+	return list("a number", s.is(s.Names, "a name"))
+}
+
+// item describes the items that the base of a field accepts
+func (s Syntax) item() string {
+	return list("a number", s.is(s.Names, "a name"), "a range", "(*)")
+}
+
+// is returns the description if the syntax allows it, otherwise nothing
+func (s Syntax) is(allowed bool, description string) string {
+	if !allowed {
+		return ""
+	}
+	return description
+}
+
+// list joins the descriptions that aren't empty e.g. (,), (-) or (/)
+func list(descriptions ...string) string {
+	kept := make([]string, 0, len(descriptions))
+	for _, description := range descriptions {
+		if description != "" {
+			kept = append(kept, description)
+		}
+	}
+	if len(kept) < 2 {
+		return strings.Join(kept, "")
+	}
+	return strings.Join(kept[:len(kept)-1], ", ") + " or " + kept[len(kept)-1]
+}
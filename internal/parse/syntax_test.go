@@ -0,0 +1,106 @@
+package parse_test
+
+import (
+	"testing"
+
+	"github.com/alistairjudson/cronparse/internal/parse"
+)
+
+func TestParser_ParseExpected(t *testing.T) {
+	tests := []struct {
+		name     string
+		parser   parse.Parser
+		input    string
+		expected string
+	}{
+		{
+			name:     "minute start",
+			parser:   parse.MinuteParser,
+			input:    "!",
+			expected: "(!) is unexpected at the start of the field, expected (*) or a number",
+		},
+		{
+			name:     "minute after a number",
+			parser:   parse.MinuteParser,
+			input:    "5x",
+			expected: "(x) is unexpected after a number, expected (,), (-) or (/)",
+		},
+		{
+			name:     "minute (?)",
+			parser:   parse.MinuteParser,
+			input:    "?",
+			expected: "(?) is unexpected in this field, expected a number, a range or (*)",
+		},
+		{
+			name:     "month start",
+			parser:   parse.MonthParser,
+			input:    "!",
+			expected: "(!) is unexpected at the start of the field, expected (*), a number or a name",
+		},
+		{
+			name:     "month after a range",
+			parser:   parse.MonthParser,
+			input:    "1-",
+			expected: "missing value after (-), expected a number or a name",
+		},
+		{
+			name:     "day of week after a name",
+			parser:   parse.DayOfWeekParser,
+			input:    "MON!",
+			expected: "(!) is unexpected after a name, expected (,), (-) or (/)",
+		},
+		{
+			name:     "quartz day of month start",
+			parser:   parse.QuartzDayOfMonthParser,
+			input:    "!",
+			expected: "(!) is unexpected at the start of the field, expected (*), (?), a number or (L)",
+		},
+		{
+			name:     "quartz day of month after a number",
+			parser:   parse.QuartzDayOfMonthParser,
+			input:    "15!",
+			expected: "(!) is unexpected after a number, expected (,), (-), (/) or (W)",
+		},
+		{
+			name:     "quartz day of month after (L)",
+			parser:   parse.QuartzDayOfMonthParser,
+			input:    "L!",
+			expected: "(!) is unexpected after (L), expected (,) or (-)",
+		},
+		{
+			name:     "quartz day of week after a number",
+			parser:   parse.QuartzDayOfWeekParser,
+			input:    "5!",
+			expected: "(!) is unexpected after a number, expected (,), (-), (/), (#) or (L)",
+		},
+		{
+			name:     "quartz day of week after a name",
+			parser:   parse.QuartzDayOfWeekParser,
+			input:    "FRI!",
+			expected: "(!) is unexpected after a name, expected (,), (-), (/) or (#)",
+		},
+		{
+			name:     "quartz day of week after (L)",
+			parser:   parse.QuartzDayOfWeekParser,
+			input:    "L!",
+			expected: "(!) is unexpected after (L), expected (,)",
+		},
+		{
+			name:     "all errors keeps the syntax",
+			parser:   parse.WithAllErrors(parse.HourParser),
+			input:    "5x",
+			expected: "(x) is unexpected after a number, expected (,), (-) or (/)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := test.parser.Parse(test.input)
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if got := err.Error(); got != test.expected {
+				t.Fatalf("expected (%s), got (%s)", test.expected, got)
+			}
+		})
+	}
+}
@@ -1,9 +1,10 @@
 package parse
 
 import (
-	"fmt"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 )
 
 var typeMap = map[TokenType]string{
@@ -13,6 +14,12 @@ var typeMap = map[TokenType]string{
 	TokenTypeDash:   "dash",
 	TokenTypeSlash:  "slash",
 	TokenTypeNumber: "number",
+	TokenTypeName:   "name",
+
+	TokenTypeNoSpecific: "no specific",
+	TokenTypeLast:       "last",
+	TokenTypeWeekday:    "weekday",
+	TokenTypeHash:       "hash",
 }
 
 // TokenType is a type that represents the type of a value within
@@ -25,17 +32,52 @@ func (t TokenType) String() string {
 	return typeMap[t]
 }
 
+// IsSpecial tells you whether the type is one of the Quartz special characters
+func (t TokenType) IsSpecial() bool {
+	return t >= TokenTypeNoSpecific
+}
+
 // Types is a slice of token types, allows methods to be added to allow
 // for pattern matching different sequences of tokens
 type Types []TokenType
 
 // StartsWith tells you whether the first type within a slice of TokenTypes
-// is the same as the type provided
-func (t Types) StartsWith(typ TokenType) bool {
+// is the same as one of the types provided
+func (t Types) StartsWith(types ...TokenType) bool {
 	if len(t) == 0 {
 		return false
 	}
-	return t[0] == typ
+	for _, typ := range types {
+		if t[0] == typ {
+			return true
+		}
+	}
+	return false
+}
+
+// Equals tells you whether the types are exactly the types provided, in the
+// same order
+func (t Types) Equals(types ...TokenType) bool {
+	if len(t) != len(types) {
+		return false
+	}
+	for i, typ := range types {
+		if t[i] != typ {
+			return false
+		}
+	}
+	return true
+}
+
+// ContainsSpecial tells you whether any of the types are Quartz special
+// characters, which are only supported by some fields
+func (t Types) ContainsSpecial() bool {
+	for _, typ := range t {
+		if typ.IsSpecial() {
+			return true
+		}
+	}
+	return false
 }
 
 // Contains tells you whether a specific TokenType is contained within this
@@ -57,6 +99,13 @@ const (
 	TokenTypeDash
 	TokenTypeSlash
 	TokenTypeNumber
+	TokenTypeName
+
+	// Quartz special characters
+	TokenTypeNoSpecific
+	TokenTypeLast
+	TokenTypeWeekday
+	TokenTypeHash
 )
 
 const eof = -1
@@ -65,15 +114,32 @@ const eof = -1
 // state machine
 type StateFunc func(t *Tokeniser) StateFunc
 
-// Token is a single token emitted from the state machine
+// Token is a single token emitted from the state machine, Pos is the byte
+// offset of the start of the token within the input. Error tokens also have
+// the error that they describe in Err.
 type Token struct {
 	Type  TokenType
 	Value string
+	Pos   int
+	Err   error
 }
 
-// NewTokenSource will return, and start the Tokeniser for a given input string
+// NewTokenSource will return, and start the Tokeniser for a given input
+// string, streaming its tokens from another goroutine. NewTokenIterator is
+// cheaper for callers that read the tokens themselves.
 func NewTokenSource(input string) TokenSource {
 	tokeniser := NewTokeniser(input)
+	tokeniser.tokens = make(chan Token)
+	go tokeniser.Run()
+	return tokeniser
+}
+
+// NewRecoveringTokenSource will return, and start a Tokeniser for a given
+// input string, which carries on after an error from the next comma
+func NewRecoveringTokenSource(input string) TokenSource {
+	tokeniser := NewTokeniser(input)
+	tokeniser.tokens = make(chan Token)
+	tokeniser.Recover = true
 	go tokeniser.Run()
 	return tokeniser
 }
@@ -84,22 +150,64 @@ type TokenSource interface {
 	Tokens() chan Token
 }
 
-// NewTokeniser will return a new *Tokeniser with the start state as lexField
+// NewTokenIterator will return a Tokeniser for a given input string, that
+// tokenises it as the tokens are asked for, on the caller's goroutine, its
+// errors say what is expected by the syntax
+func NewTokenIterator(input string, syntax Syntax) TokenIterator {
+	tokeniser := NewTokeniser(input)
+	tokeniser.Syntax = syntax
+	return tokeniser
+}
+
+// NewRecoveringTokenIterator will return a Tokeniser for a given input string,
+// that carries on after an error from the next comma
+func NewRecoveringTokenIterator(input string, syntax Syntax) TokenIterator {
+	tokeniser := NewTokeniser(input)
+	tokeniser.Syntax = syntax
+	tokeniser.Recover = true
+	return tokeniser
+}
+
+// TokenIterator is an interface that represents a type that tokens can be
+// pulled from one at a time, it returns false once there are no more tokens
+type TokenIterator interface {
+	NextToken() (Token, bool)
+}
+
+// NewTokeniser will return a new *Tokeniser with the start state as lexField,
+// whose errors say what is expected by any field
 func NewTokeniser(input string) *Tokeniser {
-	return &Tokeniser{
+	tokeniser := &Tokeniser{
 		Input:      input,
-		tokens:     make(chan Token),
 		StartState: lexField,
+		Syntax:     AnySyntax,
 	}
+	tokeniser.pending = tokeniser.buffer[:0]
+	return tokeniser
 }
 
+// maxTokensPerState is the most tokens that a single state emits, e.g. a
+// step emits the slash, the number, and an error for what follows
+const maxTokensPerState = 3
+
 // Tokeniser is a type that provides utilities for creating a state machine to
-// tokenise an input string
+// tokenise an input string, if Recover is set it will skip to the next comma
+// after an error rather than stopping. Syntax is the syntax of the field,
+// which its errors use to say what is expected.
 type Tokeniser struct {
 	Input             string
 	Start, Pos, Width int
 	tokens            chan Token
 	StartState        StateFunc
+	Recover           bool
+	Syntax            Syntax
+
+	// state is the state to run when more tokens are needed, the tokens that
+	// it emits are held in pending, which uses buffer to avoid allocating
+	state   StateFunc
+	started bool
+	pending []Token
+	buffer  [maxTokensPerState]Token
 }
 
 // Tokens implements the TokenSource interface an returns the channel that Tokens will
@@ -108,10 +216,30 @@ func (t *Tokeniser) Tokens() chan Token {
 	return t.tokens
 }
 
-// Run will run the Tokeniser until the state returned is nil
+// NextToken implements TokenIterator, it will run the states until one of
+// them emits a token, and return it. It returns false once the states have
+// finished and all of the tokens have been returned.
+func (t *Tokeniser) NextToken() (Token, bool) {
+	if !t.started {
+		t.state, t.started = t.StartState, true
+	}
+	for len(t.pending) == 0 {
+		if t.state == nil {
+			return Token{}, false
+		}
+		t.pending = t.buffer[:0]
+		t.state = t.state(t)
+	}
+	token := t.pending[0]
+	t.pending = t.pending[1:]
+	return token, true
+}
+
+// Run will run the Tokeniser until the state returned is nil, streaming the
+// tokens over the channel of a TokenSource
 func (t *Tokeniser) Run() {
-	for state := t.StartState; state != nil; {
-		state = state(t)
+	for token, ok := t.NextToken(); ok; token, ok = t.NextToken() {
+		t.tokens <- token
 	}
 	close(t.tokens)
 }
@@ -136,10 +264,11 @@ func (t *Tokeniser) Backup() {
 // Emit will emit a token of the given type, and set the start
 // of the current section to the current position
 func (t *Tokeniser) Emit(typ TokenType) {
-	t.tokens <- Token{
+	t.pending = append(t.pending, Token{
 		Type:  typ,
 		Value: t.Input[t.Start:t.Pos],
-	}
+		Pos:   t.Start,
+	})
 	t.Start = t.Pos
 }
 
@@ -150,12 +279,35 @@ func (t *Tokeniser) AcceptNumber() {
 	t.Backup()
 }
 
-// Errorf will return an error token, and will return nil as a StateFunc,
-// ending tokenisation
-func (t *Tokeniser) Errorf(format string, args ...interface{}) StateFunc {
-	t.tokens <- Token{
+// AcceptName will call next for as long as the rune is a letter
+func (t *Tokeniser) AcceptName() {
+	for r := t.Next(); r != eof && unicode.IsLetter(r); r = t.Next() {
+	}
+	t.Backup()
+}
+
+// Unexpected will emit an error token for the rune that was just read, saying
+// where it was, what was expected instead, and any suggested fixes. It will
+// return nil as a StateFunc, ending tokenisation, unless the Tokeniser
+// recovers from errors
+func (t *Tokeniser) Unexpected(context, expected string, suggestions ...cronerr.Suggestion) StateFunc {
+	offset := t.Pos - t.Width
+	err := &cronerr.SyntaxError{
+		Position:    cronerr.Position{Offset: offset, Length: t.Width},
+		Suggestions: suggestions,
+		Value:       t.Input[offset:t.Pos],
+		Context:     context,
+		Expected:    expected,
+	}
+	t.pending = append(t.pending, Token{
 		Type:  TokenTypeError,
-		Value: fmt.Sprintf(format, args...),
+		Value: err.Error(),
+		Pos:   offset,
+		Err:   err,
+	})
+	if !t.Recover {
+		return nil
 	}
-	return nil
+	t.Pos, t.Start = offset, offset
+	return lexSkip
 }
@@ -1,9 +1,11 @@
 package parse_test
 
 import (
+	"errors"
 	"reflect"
 	"testing"
 
+	"github.com/alistairjudson/cronparse/internal/cronerr"
 	"github.com/alistairjudson/cronparse/internal/parse"
 )
 
@@ -12,46 +14,113 @@ func TestNewTokenSourceFails(t *testing.T) {
 		name                 string
 		field                string
 		expectedErrorMessage string
+		expectedOffset       int
+		expectedLength       int
 	}{
 		{
 			name:                 "empty",
 			field:                "",
-			expectedErrorMessage: "input cannot be empty",
+			expectedErrorMessage: "missing value at the start of the field, expected (*), (?), a number or a name",
+			expectedOffset:       0,
+			expectedLength:       0,
 		},
 		{
-			name:                 "letter",
-			field:                "a",
-			expectedErrorMessage: "(a) is unexpected at the start of a statement, expected (* or [0-9]+)",
+			name:                 "symbol",
+			field:                "!",
+			expectedErrorMessage: "(!) is unexpected at the start of the field, expected (*), (?), a number or a name",
+			expectedOffset:       0,
+			expectedLength:       1,
+		},
+		{
+			name:                 "missing after comma",
+			field:                "1,",
+			expectedErrorMessage: "missing value after (,), expected (*), (?), a number or a name",
+			expectedOffset:       2,
+			expectedLength:       0,
 		},
 		{
 			name:                 "any + unexpected",
 			field:                "*a",
-			expectedErrorMessage: "(a) is unexpected after (*), only (/,) expected",
+			expectedErrorMessage: "(a) is unexpected after (*), expected (/) or (,)",
+			expectedOffset:       1,
+			expectedLength:       1,
 		},
 		{
 			name:                 "invalid step",
 			field:                "*/a",
-			expectedErrorMessage: "(a) is unexpected after a step, only numbers are expected",
+			expectedErrorMessage: "(a) is unexpected after (/), expected a number",
+			expectedOffset:       2,
+			expectedLength:       1,
 		},
 		{
 			name:                 "invalid after step",
 			field:                "*/12-",
-			expectedErrorMessage: "(-) is unexpected after a step, only (,) is expected",
+			expectedErrorMessage: "(-) is unexpected after a step, expected (,)",
+			expectedOffset:       4,
+			expectedLength:       1,
 		},
 		{
 			name:                 "invalid after number",
 			field:                "12a",
-			expectedErrorMessage: "(a) is unexpected after a number, only (,-/) expected",
+			expectedErrorMessage: "(a) is unexpected after a number, expected (,), (-), (/), (#), (L) or (W)",
+			expectedOffset:       2,
+			expectedLength:       1,
 		},
 		{
 			name:                 "invalid in range",
-			field:                "12-a",
-			expectedErrorMessage: "(a) is unexpected in a range, only digits are expected",
+			field:                "12-!",
+			expectedErrorMessage: "(!) is unexpected after (-), expected a number or a name",
+			expectedOffset:       3,
+			expectedLength:       1,
 		},
 		{
-			name:                 "invalid in range",
+			name:                 "invalid after name",
+			field:                "JAN1",
+			expectedErrorMessage: "(1) is unexpected after a name, expected (,), (-), (/) or (#)",
+			expectedOffset:       3,
+			expectedLength:       1,
+		},
+		{
+			name:                 "invalid after no specific",
+			field:                "?/2",
+			expectedErrorMessage: "(/) is unexpected after (?), expected (,)",
+			expectedOffset:       1,
+			expectedLength:       1,
+		},
+		{
+			name:                 "invalid after last",
+			field:                "L/2",
+			expectedErrorMessage: "(/) is unexpected after (L), expected (,) or (-)",
+			expectedOffset:       1,
+			expectedLength:       1,
+		},
+		{
+			name:                 "invalid after hash",
+			field:                "5#L",
+			expectedErrorMessage: "(L) is unexpected after (#), expected a number",
+			expectedOffset:       2,
+			expectedLength:       1,
+		},
+		{
+			name:                 "invalid after weekday",
+			field:                "15W-20",
+			expectedErrorMessage: "(-) is unexpected after a special character, expected (,)",
+			expectedOffset:       3,
+			expectedLength:       1,
+		},
+		{
+			name:                 "invalid after range",
 			field:                "12-13a",
-			expectedErrorMessage: "(a) is unexpected after a range, only (/,) expected",
+			expectedErrorMessage: "(a) is unexpected after a range, expected (/) or (,)",
+			expectedOffset:       5,
+			expectedLength:       1,
+		},
+		{
+			name:                 "multi byte rune",
+			field:                "1-5,€",
+			expectedErrorMessage: "(€) is unexpected after (,), expected (*), (?), a number or a name",
+			expectedOffset:       4,
+			expectedLength:       3,
 		},
 	}
 	for _, test := range tests {
@@ -68,6 +137,16 @@ func TestNewTokenSourceFails(t *testing.T) {
 			if gotMessage != test.expectedErrorMessage {
 				t.Fatalf("expected error message to be (%s), got (%s)", test.expectedErrorMessage, gotMessage)
 			}
+			var syntaxErr *cronerr.SyntaxError
+			if !errors.As(last.Err, &syntaxErr) {
+				t.Fatalf("expected a syntax error, got (%+v)", last.Err)
+			}
+			if syntaxErr.Offset != test.expectedOffset || syntaxErr.Length != test.expectedLength {
+				t.Fatalf(
+					"expected offset (%d) and length (%d), got (%d) and (%d)",
+					test.expectedOffset, test.expectedLength, syntaxErr.Offset, syntaxErr.Length,
+				)
+			}
 		})
 	}
 }
@@ -165,6 +244,35 @@ func TestNewTokenSourceSucceeds(t *testing.T) {
 				parse.TokenTypeNumber,
 			},
 		},
+		{
+			name:  "name",
+			field: "JAN",
+			expectedTypes: parse.Types{
+				parse.TokenTypeName,
+			},
+		},
+		{
+			name:  "name range with step and name",
+			field: "MON-FRI/2,sun",
+			expectedTypes: parse.Types{
+				parse.TokenTypeName,
+				parse.TokenTypeDash,
+				parse.TokenTypeName,
+				parse.TokenTypeSlash,
+				parse.TokenTypeNumber,
+				parse.TokenTypeComma,
+				parse.TokenTypeName,
+			},
+		},
+		{
+			name:  "number to name range",
+			field: "1-MAR",
+			expectedTypes: parse.Types{
+				parse.TokenTypeNumber,
+				parse.TokenTypeDash,
+				parse.TokenTypeName,
+			},
+		},
 		{
 			name:  "range",
 			field: "1-5",
@@ -174,6 +282,55 @@ func TestNewTokenSourceSucceeds(t *testing.T) {
 				parse.TokenTypeNumber,
 			},
 		},
+		{
+			name:          "no specific",
+			field:         "?",
+			expectedTypes: parse.Types{parse.TokenTypeNoSpecific},
+		},
+		{
+			name:  "last with offset",
+			field: "L-3",
+			expectedTypes: parse.Types{
+				parse.TokenTypeLast,
+				parse.TokenTypeDash,
+				parse.TokenTypeNumber,
+			},
+		},
+		{
+			name:  "last weekday",
+			field: "lw",
+			expectedTypes: parse.Types{
+				parse.TokenTypeLast,
+				parse.TokenTypeWeekday,
+			},
+		},
+		{
+			name:  "nearest weekday and last",
+			field: "15W,L",
+			expectedTypes: parse.Types{
+				parse.TokenTypeNumber,
+				parse.TokenTypeWeekday,
+				parse.TokenTypeComma,
+				parse.TokenTypeLast,
+			},
+		},
+		{
+			name:  "last day of week",
+			field: "5L",
+			expectedTypes: parse.Types{
+				parse.TokenTypeNumber,
+				parse.TokenTypeLast,
+			},
+		},
+		{
+			name:  "nth day of week",
+			field: "THU#3",
+			expectedTypes: parse.Types{
+				parse.TokenTypeName,
+				parse.TokenTypeHash,
+				parse.TokenTypeNumber,
+			},
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
@@ -190,6 +347,73 @@ func TestNewTokenSourceSucceeds(t *testing.T) {
 	}
 }
 
+func TestNewTokenSourcePositions(t *testing.T) {
+	expectedPositions := []int{0, 2, 3, 5, 6, 7, 8, 11, 12}
+	gotPositions := make([]int, 0, len(expectedPositions))
+	for token := range parse.NewTokenSource("10-20/5,MON,L").Tokens() {
+		gotPositions = append(gotPositions, token.Pos)
+	}
+	if !reflect.DeepEqual(expectedPositions, gotPositions) {
+		t.Fatalf("expected (%+v), got (%+v)", expectedPositions, gotPositions)
+	}
+}
+
+func TestNewRecoveringTokenSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		field          string
+		expectedTypes  parse.Types
+		expectedValues []string
+	}{
+		{
+			name:           "skips to the next comma",
+			field:          "1a2,3",
+			expectedTypes:  parse.Types{parse.TokenTypeNumber, parse.TokenTypeError, parse.TokenTypeComma, parse.TokenTypeNumber},
+			expectedValues: []string{"1", "(a) is unexpected after a number, expected (,), (-), (/), (#), (L) or (W)", ",", "3"},
+		},
+		{
+			name:  "recovers at the comma that was unexpected",
+			field: "1,,2",
+			expectedTypes: parse.Types{
+				parse.TokenTypeNumber, parse.TokenTypeComma, parse.TokenTypeError,
+				parse.TokenTypeComma, parse.TokenTypeNumber,
+			},
+			expectedValues: []string{
+				"1", ",", "(,) is unexpected after (,), expected (*), (?), a number or a name", ",", "2",
+			},
+		},
+		{
+			name:  "every error",
+			field: "*x,5-,€",
+			expectedTypes: parse.Types{
+				parse.TokenTypeAny, parse.TokenTypeError, parse.TokenTypeComma, parse.TokenTypeNumber,
+				parse.TokenTypeDash, parse.TokenTypeError, parse.TokenTypeComma, parse.TokenTypeError,
+			},
+			expectedValues: []string{
+				"*", "(x) is unexpected after (*), expected (/) or (,)", ",", "5", "-",
+				"(,) is unexpected after (-), expected a number or a name", ",",
+				"(€) is unexpected after (,), expected (*), (?), a number or a name",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gotTypes := parse.Types{}
+			gotValues := []string{}
+			for token := range parse.NewRecoveringTokenSource(test.field).Tokens() {
+				gotTypes = append(gotTypes, token.Type)
+				gotValues = append(gotValues, token.Value)
+			}
+			if !reflect.DeepEqual(test.expectedTypes, gotTypes) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expectedTypes, gotTypes)
+			}
+			if !reflect.DeepEqual(test.expectedValues, gotValues) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expectedValues, gotValues)
+			}
+		})
+	}
+}
+
 func TestTypes_Contains(t *testing.T) {
 	types := parse.Types{parse.TokenTypeNumber}
 	if !types.Contains(parse.TokenTypeNumber) {
@@ -208,12 +432,37 @@ func TestTypes_StartsWith(t *testing.T) {
 	if types.StartsWith(parse.TokenTypeAny) {
 		t.Fatal("expected types not to start with any")
 	}
+	if !types.StartsWith(parse.TokenTypeName, parse.TokenTypeNumber) {
+		t.Fatal("expected types to start with name or number")
+	}
 	empty := parse.Types{}
 	if empty.StartsWith(parse.TokenTypeAny) {
 		t.Fatal("expected types not to contain anything")
 	}
 }
 
+func TestTypes_Equals(t *testing.T) {
+	types := parse.Types{parse.TokenTypeLast, parse.TokenTypeWeekday}
+	if !types.Equals(parse.TokenTypeLast, parse.TokenTypeWeekday) {
+		t.Fatal("expected types to equal last, weekday")
+	}
+	if types.Equals(parse.TokenTypeLast) {
+		t.Fatal("expected types not to equal last")
+	}
+	if types.Equals(parse.TokenTypeWeekday, parse.TokenTypeLast) {
+		t.Fatal("expected types not to equal weekday, last")
+	}
+}
+
+func TestTypes_ContainsSpecial(t *testing.T) {
+	if !(parse.Types{parse.TokenTypeNumber, parse.TokenTypeHash}).ContainsSpecial() {
+		t.Fatal("expected types to contain a special character")
+	}
+	if (parse.Types{parse.TokenTypeNumber, parse.TokenTypeDash}).ContainsSpecial() {
+		t.Fatal("expected types not to contain a special character")
+	}
+}
+
 func TestTokenType_String(t *testing.T) {
 	gotName := parse.TokenTypeAny.String()
 	expectedName := "any"
@@ -221,3 +470,160 @@ func TestTokenType_String(t *testing.T) {
 		t.Fatalf("expected (%s), got (%s)", expectedName, gotName)
 	}
 }
+
+func collectTokens(tokens parse.TokenIterator) []parse.Token {
+	var collected []parse.Token
+	for token, ok := tokens.NextToken(); ok; token, ok = tokens.NextToken() {
+		collected = append(collected, token)
+	}
+	return collected
+}
+
+func TestNewTokenIterator(t *testing.T) {
+	tests := []struct {
+		name       string
+		field      string
+		recovering bool
+		expected   []parse.Token
+	}{
+		{
+			name:  "step",
+			field: "*/15",
+			expected: []parse.Token{
+				{Type: parse.TokenTypeAny, Value: "*", Pos: 0},
+				{Type: parse.TokenTypeSlash, Value: "/", Pos: 1},
+				{Type: parse.TokenTypeNumber, Value: "15", Pos: 2},
+			},
+		},
+		{
+			name:  "range, name and last",
+			field: "10-20/5,MON,L",
+			expected: []parse.Token{
+				{Type: parse.TokenTypeNumber, Value: "10", Pos: 0},
+				{Type: parse.TokenTypeDash, Value: "-", Pos: 2},
+				{Type: parse.TokenTypeNumber, Value: "20", Pos: 3},
+				{Type: parse.TokenTypeSlash, Value: "/", Pos: 5},
+				{Type: parse.TokenTypeNumber, Value: "5", Pos: 6},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 7},
+				{Type: parse.TokenTypeName, Value: "MON", Pos: 8},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 11},
+				{Type: parse.TokenTypeLast, Value: "L", Pos: 12},
+			},
+		},
+		{
+			name:  "day of month specials",
+			field: "LW,L-2,15W",
+			expected: []parse.Token{
+				{Type: parse.TokenTypeLast, Value: "L", Pos: 0},
+				{Type: parse.TokenTypeWeekday, Value: "W", Pos: 1},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 2},
+				{Type: parse.TokenTypeLast, Value: "L", Pos: 3},
+				{Type: parse.TokenTypeDash, Value: "-", Pos: 4},
+				{Type: parse.TokenTypeNumber, Value: "2", Pos: 5},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 6},
+				{Type: parse.TokenTypeNumber, Value: "15", Pos: 7},
+				{Type: parse.TokenTypeWeekday, Value: "W", Pos: 9},
+			},
+		},
+		{
+			name:  "day of week specials",
+			field: "5#3,6L",
+			expected: []parse.Token{
+				{Type: parse.TokenTypeNumber, Value: "5", Pos: 0},
+				{Type: parse.TokenTypeHash, Value: "#", Pos: 1},
+				{Type: parse.TokenTypeNumber, Value: "3", Pos: 2},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 3},
+				{Type: parse.TokenTypeNumber, Value: "6", Pos: 4},
+				{Type: parse.TokenTypeLast, Value: "L", Pos: 5},
+			},
+		},
+		{
+			name:  "empty",
+			field: "",
+			expected: []parse.Token{
+				{Type: parse.TokenTypeError, Value: "missing value at the start of the field, expected (*), (?), a number or a name", Pos: 0},
+			},
+		},
+		{
+			name:  "missing value after a comma",
+			field: "1,",
+			expected: []parse.Token{
+				{Type: parse.TokenTypeNumber, Value: "1", Pos: 0},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 1},
+				{Type: parse.TokenTypeError, Value: "missing value after (,), expected (*), (?), a number or a name", Pos: 2},
+			},
+		},
+		{
+			name:  "stops at the first error",
+			field: "*x,5-,€",
+			expected: []parse.Token{
+				{Type: parse.TokenTypeAny, Value: "*", Pos: 0},
+				{Type: parse.TokenTypeError, Value: "(x) is unexpected after (*), expected (/) or (,)", Pos: 1},
+			},
+		},
+		{
+			name:       "recovers after every error",
+			field:      "*x,5-,€",
+			recovering: true,
+			expected: []parse.Token{
+				{Type: parse.TokenTypeAny, Value: "*", Pos: 0},
+				{Type: parse.TokenTypeError, Value: "(x) is unexpected after (*), expected (/) or (,)", Pos: 1},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 2},
+				{Type: parse.TokenTypeNumber, Value: "5", Pos: 3},
+				{Type: parse.TokenTypeDash, Value: "-", Pos: 4},
+				{Type: parse.TokenTypeError, Value: "(,) is unexpected after (-), expected a number or a name", Pos: 5},
+				{Type: parse.TokenTypeComma, Value: ",", Pos: 5},
+				{Type: parse.TokenTypeError, Value: "(€) is unexpected after (,), expected (*), (?), a number or a name", Pos: 6},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tokens := parse.NewTokenIterator(test.field, parse.AnySyntax)
+			if test.recovering {
+				tokens = parse.NewRecoveringTokenIterator(test.field, parse.AnySyntax)
+			}
+			got := collectTokens(tokens)
+			for i, token := range got {
+				if (token.Err != nil) != (token.Type == parse.TokenTypeError) {
+					t.Fatalf("expected an error only on error tokens, got (%+v)", token)
+				}
+				got[i].Err = nil
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestTokeniser_NextTokenAfterTheEnd(t *testing.T) {
+	tokens := parse.NewTokenIterator("*", parse.AnySyntax)
+	if token, ok := tokens.NextToken(); !ok || token.Type != parse.TokenTypeAny {
+		t.Fatalf("expected (%s), got (%s)", parse.TokenTypeAny, token.Type)
+	}
+	for i := 0; i < 2; i++ {
+		if token, ok := tokens.NextToken(); ok {
+			t.Fatalf("expected no token, got (%+v)", token)
+		}
+	}
+}
+
+func BenchmarkTokeniser(b *testing.B) {
+	const field = "0-30/5,40,45,JAN-MAR,L"
+	b.Run("iterator", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			tokens := parse.NewTokenIterator(field, parse.AnySyntax)
+			for _, ok := tokens.NextToken(); ok; _, ok = tokens.NextToken() {
+			}
+		}
+	})
+	b.Run("channel", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for range parse.NewTokenSource(field).Tokens() {
+			}
+		}
+	})
+}
@@ -0,0 +1,38 @@
+package cronparse
+
+import "time"
+
+// Between will return an Iterator over all of the times that the schedule
+// runs at from start (inclusive) until end (exclusive). The times are
+// calculated lazily as the Iterator is advanced.
+func (s *Schedule) Between(start, end time.Time) *Iterator {
+	return &Iterator{
+		schedule: s,
+		current:  start.Add(-time.Nanosecond),
+		end:      end,
+	}
+}
+
+// Iterator is a type that yields the times a Schedule runs at within a window,
+// one at a time
+type Iterator struct {
+	schedule *Schedule
+	current  time.Time
+	end      time.Time
+	done     bool
+}
+
+// Next will return the next time within the window that the schedule runs at,
+// once the window has been exhausted it will return false
+func (i *Iterator) Next() (time.Time, bool) {
+	if i.done {
+		return time.Time{}, false
+	}
+	next := i.schedule.Next(i.current)
+	if next.IsZero() || !next.Before(i.end) {
+		i.done = true
+		return time.Time{}, false
+	}
+	i.current = next
+	return next, true
+}
@@ -0,0 +1,81 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestIterator_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		start, end string
+		expected   []string
+	}{
+		{
+			name:       "start is inclusive end is exclusive",
+			expression: "*/15 * * * *",
+			start:      "2020-01-01T00:00:00Z",
+			end:        "2020-01-01T01:00:00Z",
+			expected: []string{
+				"2020-01-01T00:00:00Z",
+				"2020-01-01T00:15:00Z",
+				"2020-01-01T00:30:00Z",
+				"2020-01-01T00:45:00Z",
+			},
+		},
+		{
+			name:       "across months",
+			expression: "0 0 31 * *",
+			start:      "2020-01-15T00:00:00Z",
+			end:        "2020-06-01T00:00:00Z",
+			expected: []string{
+				"2020-01-31T00:00:00Z",
+				"2020-03-31T00:00:00Z",
+				"2020-05-31T00:00:00Z",
+			},
+		},
+		{
+			name:       "empty window",
+			expression: "0 0 * * *",
+			start:      "2020-01-01T01:00:00Z",
+			end:        "2020-01-01T02:00:00Z",
+			expected:   []string{},
+		},
+		{
+			name:       "never runs",
+			expression: "0 0 30 2 *",
+			start:      "2020-01-01T00:00:00Z",
+			end:        "2021-01-01T00:00:00Z",
+			expected:   []string{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			iterator := mustSchedule(t, test.expression).Between(mustTime(t, test.start), mustTime(t, test.end))
+			got := make([]string, 0)
+			for next, ok := iterator.Next(); ok; next, ok = iterator.Next() {
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestIterator_NextExhausted(t *testing.T) {
+	iterator := mustSchedule(t, "0 0 * * *").Between(
+		mustTime(t, "2020-01-01T00:00:00Z"),
+		mustTime(t, "2020-01-01T00:00:01Z"),
+	)
+	if _, ok := iterator.Next(); !ok {
+		t.Fatal("expected a time, got none")
+	}
+	for i := 0; i < 2; i++ {
+		if next, ok := iterator.Next(); ok {
+			t.Fatalf("expected iterator to be exhausted, got (%s)", next)
+		}
+	}
+}
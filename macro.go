@@ -0,0 +1,92 @@
+package cronparse
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	macroPrefix = "@"
+	// rebootMacro is the macro for a schedule that runs when the system starts
+	rebootMacro = "@reboot"
+	// everyMacro is the macro for a schedule that runs at a fixed interval,
+	// which is given after it e.g. @every 1h30m
+	everyMacro = "@every"
+)
+
+// macros are the predefined schedules that can be used in place of the
+// components of an expression, the values of any components that are not
+// given are *
+var macros = map[string]map[string]string{
+	"@yearly":   {componentSecond: "0", componentMinute: "0", componentHour: "0", componentDayOfMonth: "1", componentMonth: "1"},
+	"@annually": {componentSecond: "0", componentMinute: "0", componentHour: "0", componentDayOfMonth: "1", componentMonth: "1"},
+	"@monthly":  {componentSecond: "0", componentMinute: "0", componentHour: "0", componentDayOfMonth: "1"},
+	"@weekly":   {componentSecond: "0", componentMinute: "0", componentHour: "0", componentDayOfWeek: "0"},
+	"@daily":    {componentSecond: "0", componentMinute: "0", componentHour: "0"},
+	"@midnight": {componentSecond: "0", componentMinute: "0", componentHour: "0"},
+	"@hourly":   {componentSecond: "0", componentMinute: "0"},
+}
+
+// isMacro tells you whether the components of an expression are a macro,
+// macros are a single component, apart from @every which is followed by its
+// interval
+func isMacro(components []string) bool {
+	return len(components) > 0 && len(components) <= 2 && strings.HasPrefix(components[0], macroPrefix)
+}
+
+// expandMacro will set the kind of schedule a macro represents on the
+// expression, and return the components that it expands to for the
+// components of the parser
+func (p Parser) expandMacro(expr *expression, components []string) ([]string, error) {
+	macro := strings.Fields(strings.Join(components, " "))
+	switch {
+	case len(macro) == 0:
+		return nil, errors.New("macro cannot be empty")
+	case macro[0] == everyMacro:
+		return nil, expr.setInterval(macro[1:])
+	case len(macro) > 1:
+		return nil, fmt.Errorf("(%s) is unexpected after macro (%s)", macro[1], macro[0])
+	case macro[0] == rebootMacro:
+		expr.kind = ScheduleKindReboot
+		return nil, nil
+	}
+	values, ok := macros[macro[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown macro (%s)", macro[0])
+	}
+	expanded := make([]string, 0, len(p))
+	for _, componentParser := range p {
+		value, ok := values[componentParser.Name]
+		switch {
+		case !ok:
+			value = "*"
+		case componentParser.Name == componentDayOfWeek && componentParser.newParser != nil:
+			// sunday is the first day of the week, whether the days are
+			// numbered from 0 or from 1 as in Quartz
+			value = strconv.Itoa(componentParser.factory.Start())
+		}
+		expanded = append(expanded, value)
+	}
+	return expanded, nil
+}
+
+// setInterval will parse the arguments of an @every macro into the interval
+// of the expression
+func (e *expression) setInterval(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("(%s) expects a single duration e.g. (%s 1h30m)", everyMacro, everyMacro)
+	}
+	interval, err := time.ParseDuration(args[0])
+	if err != nil {
+		return fmt.Errorf("(%s) %w", everyMacro, err)
+	}
+	if interval <= 0 {
+		return fmt.Errorf("(%s) interval (%s) must be greater than zero", everyMacro, interval)
+	}
+	e.kind = ScheduleKindEvery
+	e.interval = interval
+	return nil
+}
@@ -0,0 +1,168 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestParser_ParseMacros(t *testing.T) {
+	zero := []int{0}
+	tests := []struct {
+		macro    string
+		expected [][]int
+	}{
+		{
+			macro:    "@yearly",
+			expected: [][]int{zero, zero, {1}, {1}, {0, 1, 2, 3, 4, 5, 6}},
+		},
+		{
+			macro:    "@annually",
+			expected: [][]int{zero, zero, {1}, {1}, {0, 1, 2, 3, 4, 5, 6}},
+		},
+		{
+			macro:    "@monthly",
+			expected: [][]int{zero, zero, {1}, {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, {0, 1, 2, 3, 4, 5, 6}},
+		},
+		{
+			macro: "@weekly",
+			expected: [][]int{
+				zero, zero,
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				{0},
+			},
+		},
+		{
+			macro: "@daily",
+			expected: [][]int{
+				zero, zero,
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				{0, 1, 2, 3, 4, 5, 6},
+			},
+		},
+		{
+			macro: "@midnight",
+			expected: [][]int{
+				zero, zero,
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				{0, 1, 2, 3, 4, 5, 6},
+			},
+		},
+		{
+			macro: "@hourly",
+			expected: [][]int{
+				zero,
+				{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31},
+				{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+				{0, 1, 2, 3, 4, 5, 6},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.macro, func(t *testing.T) {
+			parsed, err := cronparse.CronParser.Parse([]string{test.macro})
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([][]int, 0, len(parsed))
+			for _, component := range parsed {
+				got = append(got, component.Numbers)
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParser_ParseFailsUnknownMacro(t *testing.T) {
+	tests := []string{"@fortnightly", "@DAILY", "@"}
+	for _, macro := range tests {
+		t.Run(macro, func(t *testing.T) {
+			if _, err := cronparse.CronParser.Parse([]string{macro}); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestParser_ScheduleMacro(t *testing.T) {
+	schedule, err := cronparse.CronParser.Schedule([]string{"CRON_TZ=Europe/London", "@daily"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Kind != cronparse.ScheduleKindCron {
+		t.Fatalf("expected kind (%s), got (%s)", cronparse.ScheduleKindCron, schedule.Kind)
+	}
+	expected := "2020-06-02T00:00:00+01:00"
+	got := schedule.Next(mustTime(t, "2020-06-01T12:00:00Z")).Format("2006-01-02T15:04:05Z07:00")
+	if expected != got {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+}
+
+func TestParser_ScheduleReboot(t *testing.T) {
+	parsed, err := cronparse.CronParser.Parse([]string{"@reboot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parsed) != 0 {
+		t.Fatalf("expected no components, got (%+v)", parsed)
+	}
+	schedule, err := cronparse.CronParser.Schedule([]string{"@reboot"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Kind != cronparse.ScheduleKindReboot {
+		t.Fatalf("expected kind (%s), got (%s)", cronparse.ScheduleKindReboot, schedule.Kind)
+	}
+	now := mustTime(t, "2020-01-01T00:00:00Z")
+	if next := schedule.Next(now); !next.IsZero() {
+		t.Fatalf("expected no next time, got (%s)", next)
+	}
+	if prev := schedule.Prev(now); !prev.IsZero() {
+		t.Fatalf("expected no previous time, got (%s)", prev)
+	}
+}
+
+func TestParser_ScheduleWeekly(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []cronparse.Option
+	}{
+		{name: "cron"},
+		{name: "without names", opts: []cronparse.Option{cronparse.WithNames(false)}},
+		{name: "quartz specials", opts: []cronparse.Option{cronparse.WithSeconds(true), cronparse.WithQuartzSpecials(true)}},
+		{name: "quartz weekdays", opts: []cronparse.Option{cronparse.WithQuartzWeekdays(true)}},
+		{name: "quartz with macros", opts: []cronparse.Option{cronparse.Quartz, cronparse.WithMacros(true)}},
+		{name: "quartz without names", opts: []cronparse.Option{cronparse.Quartz, cronparse.WithMacros(true), cronparse.WithNames(false)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			parser, err := cronparse.New(test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			schedule, err := parser.Schedule([]string{"@weekly"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			expected := "2020-01-05T00:00:00Z"
+			got := schedule.Next(mustTime(t, "2020-01-01T00:00:00Z")).Format("2006-01-02T15:04:05Z07:00")
+			if expected != got {
+				t.Fatalf("expected (%s), got (%s)", expected, got)
+			}
+		})
+	}
+}
+
+func TestQuartzParser_Macros(t *testing.T) {
+	if _, err := cronparse.QuartzParser.Parse([]string{"@daily"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
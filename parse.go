@@ -1,43 +1,356 @@
 package cronparse
 
 import (
+	"errors"
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/alistairjudson/cronparse/field"
+	"github.com/alistairjudson/cronparse/internal/cronerr"
+	"github.com/alistairjudson/cronparse/internal/numberer"
 	"github.com/alistairjudson/cronparse/internal/parse"
 )
 
 // CronParser is a type that can parse the components of a cron expression and
 // expand them into the values that they run on
-var CronParser = Parser{
-	newComponentParser("minute", parse.MinuteParser.Parse),
-	newComponentParser("hour", parse.HourParser.Parse),
-	newComponentParser("day of month", parse.DayOfMonthParser.Parse),
-	newComponentParser("month", parse.MonthParser.Parse),
-	newComponentParser("day of week", parse.DayOfWeekParser.Parse),
+var CronParser = mustParser(New())
+
+// SecondsCronParser is a type that can parse the components of a cron
+// expression that starts with a seconds component, as used by Quartz and
+// Spring, and expand them into the values that they run on
+var SecondsCronParser = mustParser(New(WithSeconds(true)))
+
+// YearCronParser is a type that can parse the components of a cron expression
+// that ends with a year component, as used by AWS, and expand them into the
+// values that they run on
+var YearCronParser = mustParser(New(WithYears(defaultYears.Start, defaultYears.End)))
+
+// SecondsYearCronParser is a type that can parse the components of a cron
+// expression that starts with a seconds component and ends with a year
+// component, as used by Quartz, and expand them into the values that they
+// run on
+var SecondsYearCronParser = mustParser(New(WithSeconds(true), WithYears(defaultYears.Start, defaultYears.End)))
+
+// QuartzParser is a type that can parse the components of a Quartz cron
+// expression, which starts with a seconds component, numbers the days of the
+// week from 1-7 (SUN-SAT), and allows the special characters (L W # ?) in the
+// day of month and day of week components, which are both matched, and one of
+// which has to be (?). It is the Quartz preset, so it doesn't allow macros or
+// a time zone.
+var QuartzParser = mustParser(New(Quartz))
+
+// QuartzYearParser is a type that can parse the components of a Quartz cron
+// expression that ends with a year component
+var QuartzYearParser = mustParser(QuartzParser.WithYears(defaultYears.Start, defaultYears.End))
+
+// defaultYears are the years that are allowed in the year component by default
+var defaultYears = numberer.Range{Start: 1970, End: 2099}
+
+// DetectParser will choose the parser for the components of an expression
+// based on how many there are, SecondsCronParser for six components,
+// SecondsYearCronParser for seven, and CronParser for everything else
+func DetectParser(components []string) Parser {
+	_, components, err := splitTimeZone(components)
+	if err != nil || isMacro(components) {
+		return CronParser
+	}
+	switch len(components) {
+	case len(SecondsCronParser):
+		return SecondsCronParser
+	case len(SecondsYearCronParser):
+		return SecondsYearCronParser
+	}
+	return CronParser
+}
+
+func mustParser(parser Parser, err error) Parser {
+	if err != nil {
+		panic(err)
+	}
+	return parser
 }
 
 // Parser is a type that can hold multiple ComponentParsers
 type Parser []ComponentParser
 
-// Parse will parse all of the components with their respective component parser
+// Parse will parse all of the components with their respective component parser,
+// the components may be preceded by a time zone e.g. CRON_TZ=Europe/London
+// which is validated, the location can be retrieved with Schedule. A single
+// macro component such as @daily is expanded into the components it stands
+// for, @reboot and @every have no components as they don't run at times that
+// match the components.
 func (p Parser) Parse(components []string) ([]ParsedComponent, error) {
+	expr, err := p.parse(components)
+	return expr.components, err
+}
+
+// WithYears will return a copy of the parser with a year component added to
+// the end, which accepts years from start to end. If the parser already has a
+// year component, its range is replaced.
+func (p Parser) WithYears(start, end int) (Parser, error) {
+	factory, err := numberer.NewFactory(componentYear, start, end)
+	if err != nil {
+		return nil, err
+	}
+	withYears := make(Parser, 0, len(p)+1)
+	for _, componentParser := range p {
+		if componentParser.Name != componentYear {
+			withYears = append(withYears, componentParser)
+		}
+	}
+	year := newComponentParser(componentYear, factory, parse.NewParser)
+	if p.allErrors() {
+		year = year.withAllErrors()
+	}
+	return append(withYears, year), nil
+}
+
+// WithWrapAround will return a copy of the parser that accepts ranges where
+// the start is after the end, e.g. 22-2 or FRI-MON, which wrap around from
+// the end of the values of the component to the start, so 22-2 is the hours
+// 22 23 0 1 2. Years don't wrap around, and component parsers that weren't
+// created by this package are left as they are.
+func (p Parser) WithWrapAround() Parser {
+	wrapAround := make(Parser, 0, len(p))
+	for _, componentParser := range p {
+		if componentParser.newParser != nil && componentParser.Name != componentYear {
+			componentParser = componentParser.withFactory(componentParser.factory.WithWrapAround())
+		}
+		wrapAround = append(wrapAround, componentParser)
+	}
+	return wrapAround
+}
+
+// WithAllErrors will return a copy of the parser that carries on parsing
+// after an error, so that Parse and Schedule return all of the errors in the
+// expression as Errors, rather than just the first. Within a component it
+// carries on from the next comma, component parsers that weren't created by
+// this package return their first error.
+func (p Parser) WithAllErrors() Parser {
+	allErrors := make(Parser, 0, len(p))
+	for _, componentParser := range p {
+		allErrors = append(allErrors, componentParser.withAllErrors())
+	}
+	return allErrors
+}
+
+// allErrors tells you whether the parser was created with WithAllErrors
+func (p Parser) allErrors() bool {
+	for _, componentParser := range p {
+		if componentParser.allErrors {
+			return true
+		}
+	}
+	return false
+}
+
+// macros tells you whether the parser allows macros such as @daily
+func (p Parser) macros() bool {
+	for _, componentParser := range p {
+		if componentParser.withoutMacros {
+			return false
+		}
+	}
+	return true
+}
+
+// noSpecificDay tells you whether the parser requires (?) in exactly one of
+// the day of month and day of week
+func (p Parser) noSpecificDay() bool {
+	for _, componentParser := range p {
+		if componentParser.noSpecificDay {
+			return true
+		}
+	}
+	return false
+}
+
+// timeZone tells you whether the parser allows a time zone before the
+// components
+func (p Parser) timeZone() bool {
+	for _, componentParser := range p {
+		if componentParser.withoutTimeZone {
+			return false
+		}
+	}
+	return true
+}
+
+// days returns the DayPolicy of the schedules that the parser creates
+func (p Parser) days() DayPolicy {
+	for _, componentParser := range p {
+		if componentParser.days != DaysVixie {
+			return componentParser.days
+		}
+	}
+	return DaysVixie
+}
+
+// dst returns the DSTPolicy of the schedules that the parser creates
+func (p Parser) dst() DSTPolicy {
+	for _, componentParser := range p {
+		if componentParser.dst != DSTVixie {
+			return componentParser.dst
+		}
+	}
+	return DSTVixie
+}
+
+// Schedule will parse all of the components, and create a Schedule from them
+// that can be used to find out when the expression runs
+func (p Parser) Schedule(components []string) (*Schedule, error) {
+	expr, err := p.parse(components)
+	if err != nil {
+		return nil, err
+	}
+	schedule := &Schedule{
+		Kind:     expr.kind,
+		Interval: expr.interval,
+	}
+	if expr.kind == ScheduleKindCron {
+		schedule, err = NewSchedule(expr.components)
+		if err != nil {
+			return nil, err
+		}
+		schedule.Days = p.days()
+		schedule.DST = p.dst()
+		schedule.parser = p
+	}
+	schedule.Location = expr.location
+	return schedule, nil
+}
+
+// expression is the result of parsing all of the components of an expression
+type expression struct {
+	kind       ScheduleKind
+	location   *time.Location
+	interval   time.Duration
+	components []ParsedComponent
+}
+
+func (p Parser) parse(components []string) (expression, error) {
+	if !p.timeZone() && hasTimeZone(components) {
+		return expression{}, errors.New("(time zone) is not allowed by the parser")
+	}
+	loc, components, err := splitTimeZone(components)
+	if err != nil {
+		return expression{}, err
+	}
+	// first is the index of the first component after the time zone, so that
+	// errors can point at the component in the components that were given
+	first := 0
+	if loc != nil {
+		first = 1
+	}
+	expr := expression{
+		kind:     ScheduleKindCron,
+		location: loc,
+	}
+	if isMacro(components) && !p.macros() {
+		return expression{}, fmt.Errorf("macro (%s) is not allowed by the parser", components[0])
+	}
+	macro := isMacro(components)
+	if macro {
+		components, err = p.expandMacro(&expr, components)
+		if err != nil || expr.kind != ScheduleKindCron {
+			return expr, err
+		}
+	}
 	if len(components) != len(p) {
-		return nil, fmt.Errorf("expected (%d) components, got (%d) components", len(p), len(components))
+		return expression{}, fmt.Errorf("expected (%d) components, got (%d) components", len(p), len(components))
 	}
-	parsed := make([]ParsedComponent, 0, len(p))
+	expr.components = make([]ParsedComponent, 0, len(p))
+	var errs Errors
 	for i, componentParser := range p {
 		num, err := componentParser.Parser.Parse(components[i])
 		if err != nil {
-			return nil, fmt.Errorf("(%s): %w", componentParser.Name, err)
+			err = componentParser.locate(err, first+i)
+			if !p.allErrors() {
+				return expression{}, err
+			}
+			errs = cronerr.Append(errs, err)
+			continue
 		}
-		parsed = append(parsed, ParsedComponent{
-			Name:    componentParser.Name,
-			Numbers: num.Numbers(),
-		})
+		expr.components = append(expr.components, newParsedComponent(componentParser.Name, components[i], num))
 	}
-	return parsed, nil
+	if p.noSpecificDay() && !macro {
+		if err := p.checkNoSpecificDay(components, first); err != nil {
+			if !p.allErrors() {
+				return expression{}, err
+			}
+			errs = cronerr.Append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return expression{}, errs
+	}
+	return expr, nil
+}
+
+// checkNoSpecificDay will check that exactly one of the day of month and day
+// of week is (?), as Quartz requires, the error is on the day of week unless
+// the day of month is (*) when it should have been (?)
+func (p Parser) checkNoSpecificDay(components []string, first int) error {
+	dayOfMonth, dayOfWeek := -1, -1
+	for i, componentParser := range p {
+		switch componentParser.Name {
+		case componentDayOfMonth:
+			dayOfMonth = i
+		case componentDayOfWeek:
+			dayOfWeek = i
+		}
+	}
+	if dayOfMonth < 0 || dayOfWeek < 0 {
+		return nil
+	}
+	var err error
+	blamed := dayOfWeek
+	noSpecificMonth, noSpecificWeek := isNoSpecific(components[dayOfMonth]), isNoSpecific(components[dayOfWeek])
+	switch {
+	case noSpecificMonth && noSpecificWeek:
+		err = cronerr.Suggest(&cronerr.SyntaxError{
+			Position: cronerr.Position{Length: len("?")},
+			Value:    "?",
+			Context:  "in both the day of month and the day of week",
+			Expected: "(?) in only one of them",
+		}, cronerr.Suggestion{Length: len("?"), Replacement: "*", Message: "did you mean (*)?"})
+	case !noSpecificMonth && !noSpecificWeek:
+		if components[dayOfMonth] == "*" {
+			blamed = dayOfMonth
+		}
+		err = cronerr.Suggest(&cronerr.SyntaxError{
+			Position: cronerr.Position{Length: len(components[blamed])},
+			Value:    components[blamed],
+			Context:  "without (?) in the day of month or the day of week",
+			Expected: "(?) in one of them",
+		}, cronerr.Suggestion{Length: len(components[blamed]), Replacement: "?", Message: "did you mean (?)?"})
+	default:
+		return nil
+	}
+	return p[blamed].locate(err, first+blamed)
+}
+
+// newParsedComponent will create the ParsedComponent for the text of a
+// component, from the Numberer that it was parsed into
+func newParsedComponent(name, text string, num Numberer) ParsedComponent {
+	parsed := ParsedComponent{Name: name, Text: text, Wildcard: isWildcard(text)}
+	switch num := num.(type) {
+	case Set:
+		parsed.Set = num
+		parsed.Numbers = num.Values()
+	case setNumberer:
+		parsed.Set = num.Set()
+		parsed.Numbers = parsed.Set.Values()
+	default:
+		parsed.Numbers = num.Numbers()
+		parsed.Set = NewSet(parsed.Numbers...)
+	}
+	if withMatchers, ok := num.(dateMatcherNumberer); ok {
+		parsed.Matchers = withMatchers.DateMatchers()
+	}
+	return parsed
 }
 
 // PartParser is a type that can parse part of a cron expression
@@ -50,24 +363,93 @@ type Numberer interface {
 	Numbers() []int
 }
 
+// DateMatcher is a type that matches days that can't be expanded into numbers
+// ahead of time, because they depend on the month, such as the last day of the
+// month (L) or the third thursday (THU#3)
+type DateMatcher = parse.DateMatcher
+
+// Set is a type that holds the values of a component as a bitmask, so that you
+// can tell whether it contains a value, or find the next value after one, in
+// constant time
+type Set = parse.Set
+
+// NewSet will create a Set containing the given values
+func NewSet(values ...int) Set {
+	return parse.NewSet(values...)
+}
+
+// setNumberer is a Numberer that can give you its numbers as a Set
+type setNumberer interface {
+	Set() Set
+}
+
+// dateMatcherNumberer is a Numberer that may also contain DateMatchers
+type dateMatcherNumberer interface {
+	DateMatchers() []DateMatcher
+}
+
 // ComponentParser is a parser for a specific portion of a cron expression
 type ComponentParser struct {
 	Name   string
 	Parser PartParser
+
+	// factory and newParser are used to rebuild the parser with different
+	// settings, and syntax to describe what it allows, they are only set for
+	// the parsers in this package, custom is only set for the parsers created
+	// by FieldComponent
+	factory   numberer.Factory
+	newParser func(provider numberer.Provider) parse.Parser
+	syntax    parse.Syntax
+	custom    *field.Parser
+	allErrors bool
+
+	// withoutMacros, withoutTimeZone, noSpecificDay, days and dst are set on
+	// the component parsers of the parsers created by New, for dialects
+	// without macros or time zones, that require (?) in one of the days, or
+	// that combine the days or handle the clocks changing differently
+	withoutMacros   bool
+	withoutTimeZone bool
+	noSpecificDay   bool
+	days            DayPolicy
+	dst             DSTPolicy
 }
 
-// ParsedComponent is the result of parsing a cron component
+// FieldComponent will create a ComponentParser from a field.Parser, so that a
+// field with its own name, bounds and syntax can be used in a Parser
+func FieldComponent(parser field.Parser) ComponentParser {
+	return ComponentParser{
+		Name:   parser.Name(),
+		Parser: fieldParser(parser),
+		custom: &parser,
+	}
+}
+
+// ParsedComponent is the result of parsing a cron component, Text is the
+// component as it was written, or as a macro expands it. Set holds the same
+// values as Numbers, and Matchers holds the values that depend on the date,
+// which aren't in either. Wildcard is set when the component started with (*)
+// or (?), which decides how the day of month and day of week are combined,
+// see DaysVixie, and how a minute or hour follows the clocks changing, see
+// DSTVixie. NewSchedule treats a component without Text as a wildcard when it
+// contains every value of the component.
 type ParsedComponent struct {
-	Name    string
-	Numbers []int
+	Name     string
+	Text     string
+	Numbers  []int
+	Set      Set
+	Matchers []DateMatcher
+	Wildcard bool
 }
 
 // String implements fmt.Stringer and pretty prints a parsed cron component
 func (p ParsedComponent) String() string {
-	stringNums := make([]string, 0, len(p.Numbers))
+	stringNums := make([]string, 0, len(p.Numbers)+len(p.Matchers))
 	for _, num := range p.Numbers {
 		stringNums = append(stringNums, strconv.Itoa(num))
 	}
+	for _, matcher := range p.Matchers {
+		stringNums = append(stringNums, matcher.String())
+	}
 	return fmt.Sprintf(
 		"%-14s %s",
 		p.Name,
@@ -75,10 +457,124 @@ func (p ParsedComponent) String() string {
 	)
 }
 
-func newComponentParser(name string, parserFunc parserFunc) ComponentParser {
-	return ComponentParser{
-		Name:   name,
-		Parser: parserFunc,
+// timeZonePrefixes are the prefixes that can be used on the first component of
+// an expression to set the time zone that it is evaluated in
+var timeZonePrefixes = []string{"CRON_TZ=", "TZ="}
+
+// hasTimeZone tells you whether the components start with a time zone
+func hasTimeZone(components []string) bool {
+	if len(components) == 0 {
+		return false
+	}
+	for _, prefix := range timeZonePrefixes {
+		if strings.HasPrefix(components[0], prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// splitTimeZone will split the time zone off of the front of the components
+// if there is one, returning the location and the remaining components
+func splitTimeZone(components []string) (*time.Location, []string, error) {
+	if len(components) == 0 {
+		return nil, components, nil
+	}
+	for _, prefix := range timeZonePrefixes {
+		if !strings.HasPrefix(components[0], prefix) {
+			continue
+		}
+		name := strings.TrimPrefix(components[0], prefix)
+		if name == "" {
+			return nil, nil, errors.New("(time zone) cannot be empty")
+		}
+		loc, err := time.LoadLocation(name)
+		if err != nil {
+			return nil, nil, fmt.Errorf("(time zone): %w", err)
+		}
+		return loc, components[1:], nil
+	}
+	return nil, components, nil
+}
+
+func newComponentParser(
+	name string,
+	factory numberer.Factory,
+	newParser func(provider numberer.Provider) parse.Parser,
+) ComponentParser {
+	componentParser := ComponentParser{
+		Name:      name,
+		newParser: newParser,
+	}
+	return componentParser.withFactory(factory)
+}
+
+// withFactory will return a copy of the component parser, rebuilt with a
+// different factory
+func (c ComponentParser) withFactory(factory numberer.Factory) ComponentParser {
+	parser := c.newParser(factory)
+	if c.allErrors {
+		parser = parse.WithAllErrors(parser)
+	}
+	c.Parser = parserFunc(parser.Parse)
+	c.factory = factory
+	c.syntax = parse.SyntaxOfParser(parser)
+	return c
+}
+
+// withAllErrors will return a copy of the component parser that returns all
+// of the errors in the component, if it was created by this package
+func (c ComponentParser) withAllErrors() ComponentParser {
+	c.allErrors = true
+	if c.custom != nil {
+		custom := c.custom.WithAllErrors()
+		c.Parser, c.custom = fieldParser(custom), &custom
+		return c
+	}
+	if c.newParser == nil {
+		return c
+	}
+	return c.withFactory(c.factory)
+}
+
+// locate will set the component on the errors from parsing it, errors that
+// don't have a position are prefixed with the name of the component instead
+func (c ComponentParser) locate(err error, index int) error {
+	var errs Errors
+	if !errors.As(err, &errs) {
+		if cronerr.SetComponent(err, c.Name, index, c.allowed()) {
+			return err
+		}
+		return fmt.Errorf("(%s): %w", c.Name, err)
+	}
+	located := make(Errors, 0, len(errs))
+	for _, err := range errs {
+		located = append(located, c.locate(err, index))
+	}
+	return located
+}
+
+// allowed describes the values that the component allows, if it was created
+// by this package
+func (c ComponentParser) allowed() string {
+	if c.custom != nil {
+		return c.custom.Allowed()
+	}
+	if c.newParser == nil {
+		return ""
+	}
+	return c.syntax.Allowed(c.factory.Descriptions())
+}
+
+// fieldParser adapts a field.Parser into a PartParser, its Set is used as the
+// Numberer
+func fieldParser(parser field.Parser) parserFunc {
+	return func(component string) (parse.Numberer, error) {
+		set, err := parser.Parse(component)
+		if err != nil {
+			return nil, err
+		}
+		return set, nil
 	}
 }
 
@@ -1,6 +1,7 @@
 package cronparse_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/alistairjudson/cronparse"
@@ -27,6 +28,21 @@ func TestParser_ParseSucceeds(t *testing.T) {
 	}
 }
 
+func TestParser_ParseSet(t *testing.T) {
+	res, err := cronparse.CronParser.Parse([]string{"*/15", "0", "1,15", "*", "1-5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, res := range res {
+		if got := res.Set.Values(); !reflect.DeepEqual(res.Numbers, got) {
+			t.Fatalf("expected (%+v), got (%+v)", res.Numbers, got)
+		}
+	}
+	if !res[0].Set.Contains(45) || res[0].Set.Contains(17) {
+		t.Fatalf("expected (%+v) to contain 45 and not 17", res[0].Numbers)
+	}
+}
+
 func TestParser_ParseFailsNotEnoughComponents(t *testing.T) {
 	expression := []string{"*/15", "0", "1,15", "*"}
 	_, err := cronparse.CronParser.Parse(expression)
@@ -42,3 +58,248 @@ func TestParser_ParseFailsBadComponent(t *testing.T) {
 		t.Fatal("expected an error, got none")
 	}
 }
+
+func TestParser_ParseTimeZone(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression []string
+		location   string
+	}{
+		{
+			name:       "cron tz",
+			expression: []string{"CRON_TZ=America/New_York", "0", "9", "*", "*", "1-5"},
+			location:   "America/New_York",
+		},
+		{
+			name:       "tz",
+			expression: []string{"TZ=Europe/London", "0", "9", "*", "*", "1-5"},
+			location:   "Europe/London",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := cronparse.CronParser.Parse(test.expression); err != nil {
+				t.Fatal(err)
+			}
+			schedule, err := cronparse.CronParser.Schedule(test.expression)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if schedule.Location.String() != test.location {
+				t.Fatalf("expected location (%s), got (%s)", test.location, schedule.Location)
+			}
+		})
+	}
+}
+
+func TestParser_ParseFailsTimeZone(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression []string
+	}{
+		{
+			name:       "unknown zone",
+			expression: []string{"CRON_TZ=Mars/Olympus_Mons", "0", "9", "*", "*", "1-5"},
+		},
+		{
+			name:       "empty zone",
+			expression: []string{"TZ=", "0", "9", "*", "*", "1-5"},
+		},
+		{
+			name:       "zone not at the start",
+			expression: []string{"0", "9", "*", "*", "1-5", "CRON_TZ=Europe/London"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := cronparse.CronParser.Parse(test.expression); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestParser_ScheduleWithoutTimeZone(t *testing.T) {
+	schedule, err := cronparse.CronParser.Schedule([]string{"0", "9", "*", "*", "1-5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if schedule.Location != nil {
+		t.Fatalf("expected no location, got (%s)", schedule.Location)
+	}
+	if len(schedule.Components()) != len(cronparse.CronParser) {
+		t.Fatalf("expected (%d) components, got (%d)", len(cronparse.CronParser), len(schedule.Components()))
+	}
+}
+
+func TestParser_ParseNames(t *testing.T) {
+	expression := []string{"0", "9", "*", "JAN-MAR,dec", "MON-FRI"}
+	res, err := cronparse.CronParser.Parse(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedStrings := []string{
+		"minute         0",
+		"hour           9",
+		"day of month   1 2 3 4 5 6 7 8 9 10 11 12 13 14 15 16 17 18 19 20 21 22 23 24 25 26 27 28 29 30 31",
+		"month          1 2 3 12",
+		"day of week    1 2 3 4 5",
+	}
+	for i, res := range res {
+		gotString := res.String()
+		if expectedStrings[i] != gotString {
+			t.Errorf("expected string (%s), got (%s)", expectedStrings[i], gotString)
+		}
+	}
+}
+
+func TestParser_ParseFailsNamesInNumericField(t *testing.T) {
+	expression := []string{"0", "MON", "*", "*", "*"}
+	if _, err := cronparse.CronParser.Parse(expression); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestParser_ParseDayOfWeekSeven(t *testing.T) {
+	tests := []struct {
+		name      string
+		dayOfWeek string
+		expected  string
+	}{
+		{
+			name:      "seven is sunday",
+			dayOfWeek: "7",
+			expected:  "day of week    0",
+		},
+		{
+			name:      "range to seven",
+			dayOfWeek: "5-7",
+			expected:  "day of week    0 5 6",
+		},
+		{
+			name:      "sunday twice is de-duplicated",
+			dayOfWeek: "0,1-7",
+			expected:  "day of week    0 1 2 3 4 5 6",
+		},
+		{
+			name:      "range to seven with a step",
+			dayOfWeek: "1-7/2",
+			expected:  "day of week    0 1 3 5",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := cronparse.CronParser.Parse([]string{"0", "0", "*", "*", test.dayOfWeek})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := res[len(res)-1].String(); got != test.expected {
+				t.Fatalf("expected (%s), got (%s)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestParser_WithWrapAround(t *testing.T) {
+	expression := []string{"0", "22-2/2", "28-2", "NOV-FEB", "FRI-MON"}
+	res, err := cronparse.CronParser.WithWrapAround().Parse(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedStrings := []string{
+		"minute         0",
+		"hour           0 2 22",
+		"day of month   1 2 28 29 30 31",
+		"month          1 2 11 12",
+		"day of week    0 1 5 6",
+	}
+	gotStrings := make([]string, 0, len(res))
+	for _, res := range res {
+		gotStrings = append(gotStrings, res.String())
+	}
+	if !reflect.DeepEqual(expectedStrings, gotStrings) {
+		t.Fatalf("expected (%+v), got (%+v)", expectedStrings, gotStrings)
+	}
+}
+
+func TestParser_WithWrapAroundYears(t *testing.T) {
+	parser := cronparse.YearCronParser.WithWrapAround()
+	if _, err := parser.Parse([]string{"0", "22-2", "*", "*", "*", "2030-2020"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+	if _, err := cronparse.CronParser.Parse([]string{"0", "22-2", "*", "*", "*"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestParser_ParseFailsSteps(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression []string
+		expected   string
+	}{
+		{
+			name:       "zero step",
+			expression: []string{"*/0", "*", "*", "*", "*"},
+			expected:   "(minute): step (0) must be in range (1-60)",
+		},
+		{
+			name:       "step wider than the field",
+			expression: []string{"*", "1-5/25", "*", "*", "*"},
+			expected:   "(hour): step (25) must be in range (1-24)",
+		},
+		{
+			name:       "step too large for an int",
+			expression: []string{"*", "*", "*", "*/99999999999", "*"},
+			expected:   "(month): step (99999999999) must be in range (1-12)",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := cronparse.CronParser.Parse(test.expression)
+			if err == nil {
+				t.Fatal("expected an error, got none")
+			}
+			if err.Error() != test.expected {
+				t.Fatalf("expected (%s), got (%s)", test.expected, err)
+			}
+		})
+	}
+}
+
+func BenchmarkParser_Parse(b *testing.B) {
+	benchmarks := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression []string
+	}{
+		{
+			name:       "simple",
+			parser:     cronparse.CronParser,
+			expression: []string{"*/15", "0", "1,15", "*", "1-5"},
+		},
+		{
+			name:       "lists",
+			parser:     cronparse.CronParser,
+			expression: []string{"0,10,20,30,40,50", "9-17", "1-7,15-21", "JAN-MAR,SEP-DEC", "MON,WED,FRI"},
+		},
+		{
+			name:       "quartz",
+			parser:     cronparse.QuartzParser,
+			expression: []string{"0", "15", "10", "LW,L-2,1", "*", "?"},
+		},
+		{
+			name:       "invalid",
+			parser:     cronparse.CronParser,
+			expression: []string{"*/15", "0", "1,15", "*", "1-5a"},
+		},
+	}
+	for _, benchmark := range benchmarks {
+		b.Run(benchmark.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_, _ = benchmark.parser.Parse(benchmark.expression)
+			}
+		})
+	}
+}
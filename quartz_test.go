@@ -0,0 +1,197 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestQuartzParser_Parse(t *testing.T) {
+	expression := []string{"0", "15", "10", "LW,L-2,1", "*", "?"}
+	res, err := cronparse.QuartzParser.Parse(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedStrings := []string{
+		"second         0",
+		"minute         15",
+		"hour           10",
+		"day of month   1 LW L-2",
+		"month          1 2 3 4 5 6 7 8 9 10 11 12",
+		"day of week    0 1 2 3 4 5 6",
+	}
+	gotStrings := make([]string, 0, len(res))
+	for _, res := range res {
+		gotStrings = append(gotStrings, res.String())
+	}
+	if !reflect.DeepEqual(expectedStrings, gotStrings) {
+		t.Fatalf("expected (%+v), got (%+v)", expectedStrings, gotStrings)
+	}
+}
+
+func TestQuartzParser_ParseFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+	}{
+		{
+			name:       "hash in day of month",
+			expression: "0 0 0 1#2 * ?",
+		},
+		{
+			name:       "weekday in day of week",
+			expression: "0 0 0 ? * 2W",
+		},
+		{
+			name:       "last in hour",
+			expression: "0 0 L ? * *",
+		},
+		{
+			name:       "no specific in both days",
+			expression: "0 0 0 ? * ?",
+		},
+		{
+			name:       "wildcard in both days",
+			expression: "0 0 0 * * *",
+		},
+		{
+			name:       "no specific in neither day",
+			expression: "0 0 0 L * 6L",
+		},
+		{
+			name:       "day of week zero",
+			expression: "0 0 0 ? * 0",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := cronparse.QuartzParser.Parse(strings.Fields(test.expression)); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestQuartzParser_ParseStrings(t *testing.T) {
+	weekdays, err := cronparse.New(cronparse.WithSeconds(true), cronparse.WithQuartzSpecials(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		parser    cronparse.Parser
+		dayOfWeek string
+	}{
+		{name: "quartz", parser: cronparse.QuartzParser, dayOfWeek: "6L,5#3"},
+		{name: "sunday zero", parser: weekdays, dayOfWeek: "5L,4#3"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res, err := test.parser.Parse([]string{"0", "0", "0", "?", "*", test.dayOfWeek})
+			if err != nil {
+				t.Fatal(err)
+			}
+			matchers := res[5].Matchers
+			written := make([]string, 0, len(matchers))
+			for _, matcher := range matchers {
+				written = append(written, matcher.String())
+			}
+			again, err := test.parser.Parse([]string{"0", "0", "0", "?", "*", strings.Join(written, ",")})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(matchers, again[5].Matchers) {
+				t.Fatalf("expected (%+v), got (%+v)", matchers, again[5].Matchers)
+			}
+		})
+	}
+}
+
+func TestCronParser_ParseFailsQuartz(t *testing.T) {
+	if _, err := cronparse.CronParser.Parse([]string{"0", "0", "L", "*", "?"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestQuartzSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		from       string
+		expected   []string
+	}{
+		{
+			name:       "last day of the month",
+			expression: "0 0 12 L * ?",
+			from:       "2020-01-31T12:00:00Z",
+			expected:   []string{"2020-02-29T12:00:00Z", "2020-03-31T12:00:00Z"},
+		},
+		{
+			name:       "days before the last day of the month",
+			expression: "0 0 12 L-1 * ?",
+			from:       "2021-02-01T00:00:00Z",
+			expected:   []string{"2021-02-27T12:00:00Z", "2021-03-30T12:00:00Z"},
+		},
+		{
+			name:       "last weekday of the month",
+			expression: "0 0 12 LW * ?",
+			from:       "2021-07-01T00:00:00Z",
+			expected:   []string{"2021-07-30T12:00:00Z", "2021-08-31T12:00:00Z"},
+		},
+		{
+			name:       "nearest weekday",
+			expression: "0 0 12 15W * ?",
+			from:       "2021-05-01T00:00:00Z",
+			expected:   []string{"2021-05-14T12:00:00Z", "2021-06-15T12:00:00Z"},
+		},
+		{
+			name:       "last friday of the month",
+			expression: "0 0 12 ? * 6L",
+			from:       "2021-04-01T00:00:00Z",
+			expected:   []string{"2021-04-30T12:00:00Z", "2021-05-28T12:00:00Z"},
+		},
+		{
+			name:       "third thursday of the month",
+			expression: "0 0 12 ? * THU#3",
+			from:       "2021-04-01T00:00:00Z",
+			expected:   []string{"2021-04-15T12:00:00Z", "2021-05-20T12:00:00Z"},
+		},
+		{
+			name:       "fifth monday skips months without one",
+			expression: "0 0 12 ? * 2#5",
+			from:       "2021-04-01T00:00:00Z",
+			expected:   []string{"2021-05-31T12:00:00Z", "2021-08-30T12:00:00Z"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := cronparse.QuartzParser.Schedule(strings.Fields(test.expression))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(test.expected))
+			for next := mustTime(t, test.from); len(got) < len(test.expected); {
+				next = schedule.Next(next)
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestQuartzSchedule_Prev(t *testing.T) {
+	schedule, err := cronparse.QuartzParser.Schedule(strings.Fields("0 0 12 L * ?"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := schedule.Prev(mustTime(t, "2020-03-15T00:00:00Z"))
+	expected := mustTime(t, "2020-02-29T12:00:00Z")
+	if !expected.Equal(got) {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+}
@@ -0,0 +1,477 @@
+package cronparse
+
+import (
+	"fmt"
+	"time"
+)
+
+// searchYears is the number of years that Next and Prev will search before
+// giving up, the Gregorian calendar repeats every 400 years so if a schedule
+// hasn't fired in that window it never will
+const searchYears = 400
+
+// Names of the components of a cron expression, a Schedule uses these to find
+// the component that it needs from the output of Parser.Parse
+const (
+	componentSecond     = "second"
+	componentMinute     = "minute"
+	componentHour       = "hour"
+	componentDayOfMonth = "day of month"
+	componentMonth      = "month"
+	componentDayOfWeek  = "day of week"
+	componentYear       = "year"
+)
+
+// NewSchedule will create a Schedule from the components that are produced by
+// Parser.Parse, it requires the minute, hour, day of month, month and day of
+// week components to be present. If there is no second component then the
+// schedule runs at the start of the minute, and if there is no year component
+// then it runs in every year.
+func NewSchedule(components []ParsedComponent) (*Schedule, error) {
+	byName := make(map[string]Set, len(components))
+	byName[componentSecond] = NewSet(0)
+	matchers := make(map[string][]DateMatcher, len(components))
+	wildcards := make(map[string]bool, len(components))
+	for _, component := range components {
+		set := component.Set
+		if set.Len() == 0 {
+			// components that weren't made by a parser may only have Numbers
+			set = NewSet(component.Numbers...)
+		}
+		byName[component.Name] = set
+		matchers[component.Name] = component.Matchers
+		wildcards[component.Name] = component.Wildcard
+		if component.Text == "" {
+			// components that weren't made by a parser have no text to tell,
+			// so they are a wildcard when they cover the whole component
+			wildcards[component.Name] = coversComponent(component.Name, set)
+		}
+	}
+	lookup := func(name string) (Set, error) {
+		set, ok := byName[name]
+		if !ok {
+			return Set{}, fmt.Errorf("missing (%s) component", name)
+		}
+		return set, nil
+	}
+	schedule := &Schedule{
+		components:         components,
+		dayOfMonthMatchers: matchers[componentDayOfMonth],
+		dayOfWeekMatchers:  matchers[componentDayOfWeek],
+		minuteWildcard:     wildcards[componentMinute],
+		hourWildcard:       wildcards[componentHour],
+		dayOfMonthWildcard: wildcards[componentDayOfMonth],
+		dayOfWeekWildcard:  wildcards[componentDayOfWeek],
+	}
+	for _, target := range []struct {
+		name  string
+		field *Set
+	}{
+		{name: componentSecond, field: &schedule.second},
+		{name: componentMinute, field: &schedule.minute},
+		{name: componentHour, field: &schedule.hour},
+		{name: componentDayOfMonth, field: &schedule.dayOfMonth},
+		{name: componentMonth, field: &schedule.month},
+		{name: componentDayOfWeek, field: &schedule.dayOfWeek},
+	} {
+		f, err := lookup(target.name)
+		if err != nil {
+			return nil, err
+		}
+		*target.field = f
+	}
+	if years, ok := byName[componentYear]; ok {
+		schedule.year = &years
+	}
+	return schedule, nil
+}
+
+// componentBounds are the values of the components that can be wildcards
+var componentBounds = map[string][2]int{
+	componentMinute:     {0, 59},
+	componentHour:       {0, 23},
+	componentDayOfMonth: {1, 31},
+	componentDayOfWeek:  {0, 6},
+}
+
+// coversComponent tells you whether a set contains every value of a component
+func coversComponent(name string, set Set) bool {
+	bounds, ok := componentBounds[name]
+	if !ok {
+		return false
+	}
+	for value := bounds[0]; value <= bounds[1]; value++ {
+		if !set.Contains(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// ScheduleKind is the kind of a Schedule, which decides how it runs
+type ScheduleKind int
+
+// Kinds of schedule
+const (
+	// ScheduleKindCron is a schedule that runs at the times matched by the
+	// components of a cron expression
+	ScheduleKindCron ScheduleKind = iota
+	// ScheduleKindReboot is a schedule that runs when the system starts, it is
+	// not time based so it never has a next or previous time
+	ScheduleKindReboot
+	// ScheduleKindEvery is a schedule that runs at a fixed interval, from an
+	// anchor time
+	ScheduleKindEvery
+)
+
+var scheduleKindNames = map[ScheduleKind]string{
+	ScheduleKindCron:   "cron",
+	ScheduleKindReboot: "reboot",
+	ScheduleKindEvery:  "every",
+}
+
+// String implements fmt.Stringer and returns the name of the kind
+func (k ScheduleKind) String() string {
+	return scheduleKindNames[k]
+}
+
+// Schedule is a parsed cron expression that can tell you when it will next
+// run, and when it last ran.
+type Schedule struct {
+	// Kind is the kind of the schedule, reboot schedules never run at a time
+	Kind ScheduleKind
+	// Interval is the time between runs of an every schedule
+	Interval time.Duration
+	// Anchor is a time that an every schedule runs at, it also runs at every
+	// Interval before and after it. If it is the zero time, the runs are
+	// aligned to the zero time, so an interval that divides a day evenly will
+	// run at the same times each day in UTC.
+	Anchor time.Time
+	// Location is the time zone that the schedule is evaluated in, if it is
+	// nil the location of the time passed to Next or Prev is used
+	Location *time.Location
+	// DST is the policy used for times that are skipped or repeated when the
+	// clocks change in Location
+	DST DSTPolicy
+	// Days is the policy used to combine the day of month and day of week
+	Days DayPolicy
+
+	components                                         []ParsedComponent
+	second, minute, hour, dayOfMonth, month, dayOfWeek Set
+	// year is nil when the schedule runs in every year
+	year *Set
+	// dayOfMonthMatchers and dayOfWeekMatchers match the days that depend on
+	// the month, such as the last day of the month
+	dayOfMonthMatchers, dayOfWeekMatchers []DateMatcher
+	// minuteWildcard and hourWildcard are set when the components were
+	// wildcards, for the DSTPolicy, and dayOfMonthWildcard and
+	// dayOfWeekWildcard are for the DayPolicy
+	minuteWildcard, hourWildcard, dayOfMonthWildcard, dayOfWeekWildcard bool
+	// parser is the parser that the schedule was created by, if it was, which
+	// Explain uses to find the item of a component that matched
+	parser Parser
+}
+
+// Components returns the parsed components that the schedule was created from
+func (s *Schedule) Components() []ParsedComponent {
+	return s.components
+}
+
+// Next will return the first time strictly after t that the schedule runs at,
+// if the schedule will never run then the zero time is returned
+func (s *Schedule) Next(t time.Time) time.Time {
+	switch s.Kind {
+	case ScheduleKindReboot:
+		return time.Time{}
+	case ScheduleKindEvery:
+		return s.nextInterval(t)
+	}
+	t = t.In(s.location(t))
+	from := wallClockOf(t)
+	var earliest time.Time
+	// if the clocks are about to go back, the wall clock times leading up to
+	// t will happen again after t
+	if back := overlapAhead(t); back > 0 {
+		end := from
+		for c, ok := s.nextWall(wallClockOf(t.Add(-back))); ok && !end.before(c); c, ok = s.nextWall(c.add(1)) {
+			for _, instant := range s.instants(c, t.Location()) {
+				if instant.After(t) && (earliest.IsZero() || instant.Before(earliest)) {
+					earliest = instant
+				}
+			}
+		}
+	}
+	for c, ok := s.nextWall(from.add(1)); ok; c, ok = s.nextWall(c.add(1)) {
+		for _, instant := range s.instants(c, t.Location()) {
+			if !instant.After(t) {
+				continue
+			}
+			if earliest.IsZero() || instant.Before(earliest) {
+				return instant
+			}
+			return earliest
+		}
+	}
+	return earliest
+}
+
+// Prev will return the last time strictly before t that the schedule ran at,
+// if the schedule has never run then the zero time is returned
+func (s *Schedule) Prev(t time.Time) time.Time {
+	switch s.Kind {
+	case ScheduleKindReboot:
+		return time.Time{}
+	case ScheduleKindEvery:
+		return s.prevInterval(t)
+	}
+	t = t.In(s.location(t))
+	from := wallClockOf(t)
+	var latest time.Time
+	// if the clocks have recently gone back, the wall clock times after t
+	// have already happened before t, and the wall clock times before t in
+	// the overlap may have happened a second time since the earlier ones
+	if back := overlapBehind(t); back > 0 {
+		start := wallClockOf(from.in(time.UTC).Add(-back))
+		for c, ok := s.prevWall(wallClockOf(t.Add(back))); ok && !c.before(start); c, ok = s.prevWall(c.add(-1)) {
+			for _, instant := range s.instants(c, t.Location()) {
+				if instant.Before(t) && instant.After(latest) {
+					latest = instant
+				}
+			}
+		}
+	}
+	for c, ok := s.prevWall(from); ok; c, ok = s.prevWall(c.add(-1)) {
+		instants := s.instants(c, t.Location())
+		for i := len(instants) - 1; i >= 0; i-- {
+			if !instants[i].Before(t) {
+				continue
+			}
+			if instants[i].After(latest) {
+				return instants[i]
+			}
+			return latest
+		}
+	}
+	return latest
+}
+
+func (s *Schedule) location(t time.Time) *time.Location {
+	if s.Location != nil {
+		return s.Location
+	}
+	return t.Location()
+}
+
+// nextWall will return the first wall clock time on or after c that matches
+// the schedule
+func (s *Schedule) nextWall(c wallClock) (wallClock, bool) {
+	for limit := c.year + searchYears; c.year <= limit; {
+		year, ok := s.nextYear(c.year)
+		if !ok {
+			return wallClock{}, false
+		}
+		if year != c.year {
+			c = wallClock{year: year, month: 1, day: 1}
+		}
+		month, ok := s.month.NextSetBit(c.month)
+		if !ok {
+			c = wallClock{year: c.year + 1, month: 1, day: 1}
+			continue
+		}
+		if month != c.month {
+			c = wallClock{year: c.year, month: month, day: 1}
+		}
+		day, ok := s.nextDay(c.year, c.month, c.day)
+		if !ok {
+			c = wallClock{year: c.year, month: c.month + 1, day: 1}
+			continue
+		}
+		if day != c.day {
+			c.day, c.hour, c.minute, c.second = day, 0, 0, 0
+		}
+		hour, ok := s.hour.NextSetBit(c.hour)
+		if !ok {
+			c.day, c.hour, c.minute, c.second = c.day+1, 0, 0, 0
+			continue
+		}
+		if hour != c.hour {
+			c.hour, c.minute, c.second = hour, 0, 0
+		}
+		minute, ok := s.minute.NextSetBit(c.minute)
+		if !ok {
+			c.hour, c.minute, c.second = c.hour+1, 0, 0
+			continue
+		}
+		if minute != c.minute {
+			c.minute, c.second = minute, 0
+		}
+		second, ok := s.second.NextSetBit(c.second)
+		if !ok {
+			c.minute, c.second = c.minute+1, 0
+			continue
+		}
+		c.second = second
+		return c, true
+	}
+	return wallClock{}, false
+}
+
+// prevWall will return the last wall clock time on or before c that matches
+// the schedule
+func (s *Schedule) prevWall(c wallClock) (wallClock, bool) {
+	for limit := c.year - searchYears; c.year >= limit; {
+		year, ok := s.prevYear(c.year)
+		if !ok {
+			return wallClock{}, false
+		}
+		if year != c.year {
+			c = endOfMonth(year, 12)
+		}
+		month, ok := s.month.PrevSetBit(c.month)
+		if !ok {
+			c = endOfMonth(c.year-1, 12)
+			continue
+		}
+		if month != c.month {
+			c = endOfMonth(c.year, month)
+		}
+		day, ok := s.prevDay(c.year, c.month, c.day)
+		if !ok {
+			c = endOfMonth(c.year, c.month-1)
+			continue
+		}
+		if day != c.day {
+			c.day, c.hour, c.minute, c.second = day, 23, 59, 59
+		}
+		hour, ok := s.hour.PrevSetBit(c.hour)
+		if !ok {
+			c.day, c.hour, c.minute, c.second = c.day-1, 23, 59, 59
+			continue
+		}
+		if hour != c.hour {
+			c.hour, c.minute, c.second = hour, 59, 59
+		}
+		minute, ok := s.minute.PrevSetBit(c.minute)
+		if !ok {
+			c.hour, c.minute, c.second = c.hour-1, 59, 59
+			continue
+		}
+		if minute != c.minute {
+			c.minute, c.second = minute, 59
+		}
+		second, ok := s.second.PrevSetBit(c.second)
+		if !ok {
+			c.minute, c.second = c.minute-1, 59
+			continue
+		}
+		c.second = second
+		return c, true
+	}
+	return wallClock{}, false
+}
+
+// nextYear will return the first year on or after the given year that the
+// schedule runs in
+func (s *Schedule) nextYear(year int) (int, bool) {
+	if s.year == nil {
+		return year, true
+	}
+	return s.year.NextSetBit(year)
+}
+
+// prevYear will return the last year on or before the given year that the
+// schedule runs in
+func (s *Schedule) prevYear(year int) (int, bool) {
+	if s.year == nil {
+		return year, true
+	}
+	return s.year.PrevSetBit(year)
+}
+
+// nextDay will return the first day on or after the given day in the month
+// that the schedule runs on
+func (s *Schedule) nextDay(year, month, day int) (int, bool) {
+	for last := daysIn(year, month); day <= last; day++ {
+		if s.dayMatches(year, month, day) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// prevDay will return the last day on or before the given day in the month
+// that the schedule runs on
+func (s *Schedule) prevDay(year, month, day int) (int, bool) {
+	if last := daysIn(year, month); day > last {
+		day = last
+	}
+	for ; day >= 1; day-- {
+		if s.dayMatches(year, month, day) {
+			return day, true
+		}
+	}
+	return 0, false
+}
+
+// daysIn returns the number of days in a month, taking leap years into account
+func daysIn(year, month int) int {
+	return time.Date(year, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// wallClock is a time as it is shown on a clock, the values are allowed to
+// overflow the bounds of their unit while searching, as they will then
+// fail to match the schedule and cause the next unit up to move on
+type wallClock struct {
+	year, month, day, hour, minute, second int
+}
+
+// wallClockOf returns the wall clock time of t, truncated to the second
+func wallClockOf(t time.Time) wallClock {
+	year, month, day := t.Date()
+	return wallClock{
+		year:   year,
+		month:  int(month),
+		day:    day,
+		hour:   t.Hour(),
+		minute: t.Minute(),
+		second: t.Second(),
+	}
+}
+
+// endOfMonth returns the last second of a given month, if the month is 0
+// then it's the last second of the year before
+func endOfMonth(year, month int) wallClock {
+	if month < 1 {
+		year, month = year-1, 12
+	}
+	return wallClock{
+		year:   year,
+		month:  month,
+		day:    daysIn(year, month),
+		hour:   23,
+		minute: 59,
+		second: 59,
+	}
+}
+
+// add will add a number of seconds to the wall clock, without normalising it
+func (w wallClock) add(seconds int) wallClock {
+	w.second += seconds
+	return w
+}
+
+// before tells you whether w is earlier on the wall clock than other
+func (w wallClock) before(other wallClock) bool {
+	a := [...]int{w.year, w.month, w.day, w.hour, w.minute, w.second}
+	b := [...]int{other.year, other.month, other.day, other.hour, other.minute, other.second}
+	for i := range a {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return false
+}
+
+func (w wallClock) in(loc *time.Location) time.Time {
+	return time.Date(w.year, time.Month(w.month), w.day, w.hour, w.minute, w.second, 0, loc)
+}
@@ -0,0 +1,272 @@
+package cronparse_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func mustSchedule(t *testing.T, expression string) *cronparse.Schedule {
+	t.Helper()
+	schedule, err := cronparse.CronParser.Schedule(strings.Fields(expression))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return schedule
+}
+
+func mustTime(t *testing.T, value string) time.Time {
+	t.Helper()
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parsed
+}
+
+func TestSchedule_Next(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		from       string
+		expected   string
+	}{
+		{
+			name:       "every minute",
+			expression: "* * * * *",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   "2020-01-01T00:01:00Z",
+		},
+		{
+			name:       "every minute mid minute",
+			expression: "* * * * *",
+			from:       "2020-01-01T00:00:30.5Z",
+			expected:   "2020-01-01T00:01:00Z",
+		},
+		{
+			name:       "steps within the hour",
+			expression: "*/15 * * * *",
+			from:       "2020-01-01T00:16:00Z",
+			expected:   "2020-01-01T00:30:00Z",
+		},
+		{
+			name:       "rolls over the hour",
+			expression: "*/15 * * * *",
+			from:       "2020-01-01T00:45:00Z",
+			expected:   "2020-01-01T01:00:00Z",
+		},
+		{
+			name:       "rolls over the day",
+			expression: "30 9 * * *",
+			from:       "2020-01-01T09:30:00Z",
+			expected:   "2020-01-02T09:30:00Z",
+		},
+		{
+			name:       "rolls over the year",
+			expression: "0 0 1 1 *",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   "2021-01-01T00:00:00Z",
+		},
+		{
+			name:       "skips short months",
+			expression: "0 0 31 * *",
+			from:       "2020-01-31T00:00:00Z",
+			expected:   "2020-03-31T00:00:00Z",
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 2 *",
+			from:       "2020-03-01T00:00:00Z",
+			expected:   "2024-02-29T00:00:00Z",
+		},
+		{
+			name:       "leap day skips centuries",
+			expression: "0 0 29 2 *",
+			from:       "2096-03-01T00:00:00Z",
+			expected:   "2104-02-29T00:00:00Z",
+		},
+		{
+			name:       "day of week",
+			expression: "0 12 * * 1-5",
+			from:       "2020-01-03T12:00:00Z",
+			expected:   "2020-01-06T12:00:00Z",
+		},
+		{
+			name:       "day of week seven is sunday",
+			expression: "0 12 * * 7",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   "2020-01-05T12:00:00Z",
+		},
+		{
+			name:       "day of month or day of week",
+			expression: "0 0 13 * 5",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   "2020-01-03T00:00:00Z",
+		},
+		{
+			name:       "never",
+			expression: "0 0 30 2 *",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   "0001-01-01T00:00:00Z",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := mustTime(t, test.expected)
+			got := mustSchedule(t, test.expression).Next(mustTime(t, test.from))
+			if !expected.Equal(got) {
+				t.Fatalf("expected (%s), got (%s)", expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_Prev(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		from       string
+		expected   string
+	}{
+		{
+			name:       "every minute",
+			expression: "* * * * *",
+			from:       "2020-01-01T00:01:00Z",
+			expected:   "2020-01-01T00:00:00Z",
+		},
+		{
+			name:       "every minute mid minute",
+			expression: "* * * * *",
+			from:       "2020-01-01T00:01:30Z",
+			expected:   "2020-01-01T00:01:00Z",
+		},
+		{
+			name:       "steps within the hour",
+			expression: "*/15 * * * *",
+			from:       "2020-01-01T00:16:00Z",
+			expected:   "2020-01-01T00:15:00Z",
+		},
+		{
+			name:       "rolls back over the hour",
+			expression: "*/15 * * * *",
+			from:       "2020-01-01T01:00:00Z",
+			expected:   "2020-01-01T00:45:00Z",
+		},
+		{
+			name:       "rolls back over the day",
+			expression: "30 9 * * *",
+			from:       "2020-01-02T09:30:00Z",
+			expected:   "2020-01-01T09:30:00Z",
+		},
+		{
+			name:       "rolls back over the year",
+			expression: "0 0 31 12 *",
+			from:       "2020-12-30T00:00:00Z",
+			expected:   "2019-12-31T00:00:00Z",
+		},
+		{
+			name:       "skips short months",
+			expression: "0 0 31 * *",
+			from:       "2020-03-31T00:00:00Z",
+			expected:   "2020-01-31T00:00:00Z",
+		},
+		{
+			name:       "leap day",
+			expression: "0 0 29 2 *",
+			from:       "2024-02-28T00:00:00Z",
+			expected:   "2020-02-29T00:00:00Z",
+		},
+		{
+			name:       "leap day skips centuries",
+			expression: "0 0 29 2 *",
+			from:       "2104-02-28T00:00:00Z",
+			expected:   "2096-02-29T00:00:00Z",
+		},
+		{
+			name:       "end of the month",
+			expression: "59 23 28-31 2 *",
+			from:       "2021-03-01T00:00:00Z",
+			expected:   "2021-02-28T23:59:00Z",
+		},
+		{
+			name:       "day of week",
+			expression: "0 12 * * 1-5",
+			from:       "2020-01-06T12:00:00Z",
+			expected:   "2020-01-03T12:00:00Z",
+		},
+		{
+			name:       "never",
+			expression: "0 0 31 4 *",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   "0001-01-01T00:00:00Z",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			expected := mustTime(t, test.expected)
+			got := mustSchedule(t, test.expression).Prev(mustTime(t, test.from))
+			if !expected.Equal(got) {
+				t.Fatalf("expected (%s), got (%s)", expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_NextInLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	from := time.Date(2020, 1, 1, 8, 0, 0, 0, loc)
+	got := mustSchedule(t, "30 9 * * *").Next(from)
+	expected := time.Date(2020, 1, 1, 9, 30, 0, 0, loc)
+	if !expected.Equal(got) {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+	if got.Location() != loc {
+		t.Fatalf("expected location (%s), got (%s)", loc, got.Location())
+	}
+}
+
+func TestNewScheduleFailsMissingComponent(t *testing.T) {
+	_, err := cronparse.NewSchedule([]cronparse.ParsedComponent{
+		{Name: "minute", Numbers: []int{0}},
+	})
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestNewScheduleFromNumbers(t *testing.T) {
+	schedule, err := cronparse.NewSchedule([]cronparse.ParsedComponent{
+		{Name: "minute", Numbers: []int{45, 15}},
+		{Name: "hour", Numbers: []int{9}},
+		{Name: "day of month", Set: cronparse.NewSet(1)},
+		{Name: "month", Numbers: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}},
+		{Name: "day of week", Numbers: []int{0, 1, 2, 3, 4, 5, 6}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		from, expected string
+	}{
+		{from: "2020-01-01T09:20:00Z", expected: "2020-01-01T09:45:00Z"},
+		{from: "2020-01-01T10:00:00Z", expected: "2020-02-01T09:15:00Z"},
+	} {
+		got := schedule.Next(mustTime(t, test.from))
+		expected := mustTime(t, test.expected)
+		if !expected.Equal(got) {
+			t.Fatalf("expected (%s), got (%s)", expected, got)
+		}
+	}
+	if schedule.EitherDay() {
+		t.Fatal("expected (false), got (true)")
+	}
+}
+
+func TestParser_ScheduleFails(t *testing.T) {
+	_, err := cronparse.CronParser.Schedule([]string{"*", "*", "*", "*"})
+	if err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
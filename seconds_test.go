@@ -0,0 +1,158 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestSecondsCronParser_Parse(t *testing.T) {
+	expression := []string{"*/20", "*/15", "0", "1,15", "*", "1-5"}
+	res, err := cronparse.SecondsCronParser.Parse(expression)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedStrings := []string{
+		"second         0 20 40",
+		"minute         0 15 30 45",
+		"hour           0",
+		"day of month   1 15",
+		"month          1 2 3 4 5 6 7 8 9 10 11 12",
+		"day of week    1 2 3 4 5",
+	}
+	gotStrings := make([]string, 0, len(res))
+	for _, res := range res {
+		gotStrings = append(gotStrings, res.String())
+	}
+	if !reflect.DeepEqual(expectedStrings, gotStrings) {
+		t.Fatalf("expected (%+v), got (%+v)", expectedStrings, gotStrings)
+	}
+}
+
+func TestSecondsCronParser_ParseFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression []string
+	}{
+		{
+			name:       "second out of range",
+			expression: []string{"60", "*", "*", "*", "*", "*"},
+		},
+		{
+			name:       "five components",
+			expression: []string{"*", "*", "*", "*", "*"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := cronparse.SecondsCronParser.Parse(test.expression); err == nil {
+				t.Fatal("expected an error, got none")
+			}
+		})
+	}
+}
+
+func TestDetectParser(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		expected   int
+	}{
+		{
+			name:       "five components",
+			expression: "* * * * *",
+			expected:   len(cronparse.CronParser),
+		},
+		{
+			name:       "six components",
+			expression: "0 * * * * *",
+			expected:   len(cronparse.SecondsCronParser),
+		},
+		{
+			name:       "six components with a time zone",
+			expression: "TZ=UTC 0 * * * * *",
+			expected:   len(cronparse.SecondsCronParser),
+		},
+		{
+			name:       "five components with a time zone",
+			expression: "TZ=UTC * * * * *",
+			expected:   len(cronparse.CronParser),
+		},
+		{
+			name:       "macro",
+			expression: "@every 1h",
+			expected:   len(cronparse.CronParser),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := cronparse.DetectParser(strings.Fields(test.expression))
+			if len(got) != test.expected {
+				t.Fatalf("expected a parser with (%d) components, got (%d)", test.expected, len(got))
+			}
+		})
+	}
+}
+
+func TestSchedule_NextSeconds(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		from       string
+		expected   []string
+	}{
+		{
+			name:       "every 20 seconds",
+			expression: "*/20 * * * * *",
+			from:       "2020-01-01T00:00:45Z",
+			expected:   []string{"2020-01-01T00:01:00Z", "2020-01-01T00:01:20Z", "2020-01-01T00:01:40Z"},
+		},
+		{
+			name:       "rolls over the day",
+			expression: "30 59 23 * * *",
+			from:       "2020-01-01T23:59:30Z",
+			expected:   []string{"2020-01-02T23:59:30Z"},
+		},
+		{
+			name:       "macro runs on the first second",
+			expression: "@hourly",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   []string{"2020-01-01T01:00:00Z", "2020-01-01T02:00:00Z"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := cronparse.SecondsCronParser.Schedule(strings.Fields(test.expression))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(test.expected))
+			for next := mustTime(t, test.from); len(got) < len(test.expected); {
+				next = schedule.Next(next)
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_PrevSeconds(t *testing.T) {
+	schedule, err := cronparse.SecondsCronParser.Schedule([]string{"15,45", "0", "*", "*", "*", "*"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"2020-01-01T01:00:15Z", "2020-01-01T00:00:45Z", "2020-01-01T00:00:15Z"}
+	got := make([]string, 0, len(expected))
+	for prev := mustTime(t, "2020-01-01T01:00:30Z"); len(got) < len(expected); {
+		prev = schedule.Prev(prev)
+		got = append(got, prev.Format(time.RFC3339))
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}
@@ -0,0 +1,142 @@
+package cronparse_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alistairjudson/cronparse"
+)
+
+func TestParser_WithYears(t *testing.T) {
+	parser, err := cronparse.CronParser.WithYears(2020, 2030)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(parser) != len(cronparse.CronParser)+1 {
+		t.Fatalf("expected (%d) components, got (%d)", len(cronparse.CronParser)+1, len(parser))
+	}
+	parsed, err := parser.Parse([]string{"0", "0", "1", "1", "*", "*/5"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "year           2020 2025 2030"
+	if got := parsed[len(parsed)-1].String(); got != expected {
+		t.Fatalf("expected (%s), got (%s)", expected, got)
+	}
+	if _, err := parser.Parse([]string{"0", "0", "1", "1", "*", "2031"}); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+
+	replaced, err := parser.WithYears(2000, 2010)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replaced) != len(parser) {
+		t.Fatalf("expected (%d) components, got (%d)", len(parser), len(replaced))
+	}
+	if _, err := replaced.Parse([]string{"0", "0", "1", "1", "*", "2000"}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParser_WithYearsFails(t *testing.T) {
+	if _, err := cronparse.CronParser.WithYears(2030, 2020); err == nil {
+		t.Fatal("expected an error, got none")
+	}
+}
+
+func TestYearParsers(t *testing.T) {
+	tests := []struct {
+		name       string
+		parser     cronparse.Parser
+		expression string
+	}{
+		{
+			name:       "aws",
+			parser:     cronparse.YearCronParser,
+			expression: "0 10 * * * 2021",
+		},
+		{
+			name:       "quartz",
+			parser:     cronparse.SecondsYearCronParser,
+			expression: "0 0 12 * * MON-FRI 1970-2099",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			components := strings.Fields(test.expression)
+			if _, err := test.parser.Parse(components); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestDetectParserYears(t *testing.T) {
+	got := cronparse.DetectParser(strings.Fields("0 0 12 * * MON-FRI 2020"))
+	if len(got) != len(cronparse.SecondsYearCronParser) {
+		t.Fatalf("expected a parser with (%d) components, got (%d)", len(cronparse.SecondsYearCronParser), len(got))
+	}
+}
+
+func TestSchedule_NextYears(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		from       string
+		expected   []string
+	}{
+		{
+			name:       "skips to the first year",
+			expression: "0 0 1 1 * 2025,2027",
+			from:       "2020-06-01T00:00:00Z",
+			expected:   []string{"2025-01-01T00:00:00Z", "2027-01-01T00:00:00Z", "0001-01-01T00:00:00Z"},
+		},
+		{
+			name:       "never runs again",
+			expression: "0 0 * * * 2020-2030",
+			from:       "2030-12-31T00:00:00Z",
+			expected:   []string{"0001-01-01T00:00:00Z"},
+		},
+		{
+			name:       "leap day in a range of years",
+			expression: "0 0 29 2 * 2021-2030",
+			from:       "2020-01-01T00:00:00Z",
+			expected:   []string{"2024-02-29T00:00:00Z", "2028-02-29T00:00:00Z", "0001-01-01T00:00:00Z"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			schedule, err := cronparse.YearCronParser.Schedule(strings.Fields(test.expression))
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(test.expected))
+			for next := mustTime(t, test.from); len(got) < len(test.expected); {
+				next = schedule.Next(next)
+				got = append(got, next.Format(time.RFC3339))
+			}
+			if !reflect.DeepEqual(test.expected, got) {
+				t.Fatalf("expected (%+v), got (%+v)", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestSchedule_PrevYears(t *testing.T) {
+	schedule, err := cronparse.YearCronParser.Schedule(strings.Fields("0 0 1 1 * 2021,2023"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"2023-01-01T00:00:00Z", "2021-01-01T00:00:00Z", "0001-01-01T00:00:00Z"}
+	got := make([]string, 0, len(expected))
+	for prev := mustTime(t, "2030-01-01T00:00:00Z"); len(got) < len(expected); {
+		prev = schedule.Prev(prev)
+		got = append(got, prev.Format(time.RFC3339))
+	}
+	if !reflect.DeepEqual(expected, got) {
+		t.Fatalf("expected (%+v), got (%+v)", expected, got)
+	}
+}